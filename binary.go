@@ -0,0 +1,76 @@
+package enum
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. Enums are
+// encoded compactly as their ID, using a varint. This is picked up by
+// encoding/gob (see RegisterGob) and by binary formats like msgpack that fall
+// back to encoding.BinaryMarshaler.
+func (e internalEnumWrapper[T]) MarshalBinary() ([]byte, error) {
+	if !e.Valid() {
+		return nil, fmt.Errorf("enum not initialized")
+	}
+
+	buf := make([]byte, binary.MaxVarintLen64)
+
+	if isSigned[T]() {
+		return buf[:binary.PutVarint(buf, int64(e.internalEnum.id))], nil
+	}
+
+	return buf[:binary.PutUvarint(buf, uint64(e.internalEnum.id))], nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// accepts data produced by MarshalBinary.
+func (e *internalEnumWrapper[T]) UnmarshalBinary(data []byte) error {
+	var id T
+	var n int
+
+	if isSigned[T]() {
+		var v int64
+		v, n = binary.Varint(data)
+		id = T(v)
+
+		if int64(id) != v {
+			return fmt.Errorf("invalid enum binary data: ID %d out of range", v)
+		}
+	} else {
+		var v uint64
+		v, n = binary.Uvarint(data)
+		id = T(v)
+
+		if uint64(id) != v {
+			return fmt.Errorf("invalid enum binary data: ID %d out of range", v)
+		}
+	}
+
+	if n <= 0 || n != len(data) {
+		return fmt.Errorf("invalid enum binary data %x", data)
+	}
+
+	var err error
+	e.internalEnum, err = getInternalEnumForID(id)
+	if err != nil {
+		return fmt.Errorf("invalid enum binary data: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterGob registers Enum[T], []Enum[T] and EnumSet[T] with encoding/gob.
+// This is only needed to transmit them as interface values (for example, in
+// fields of type any), as gob requires concrete types to be registered for
+// those. Fields with concrete enum types work without it. Maps keyed by
+// Enum[T] depend on their value type, so callers transmitting them as
+// interface values must register them with gob.Register themselves.
+func RegisterGob[T constraints.Integer]() {
+	gob.Register(Enum[T]{})
+	gob.Register([]Enum[T]{})
+	gob.Register(EnumSet[T]{})
+}
@@ -0,0 +1,124 @@
+package enum
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestEnum_MarshalBinary(t *testing.T) {
+	for _, role := range EnumsByType[Role]() {
+		data, err := role.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if len(data) != 1 {
+			t.Errorf("expected 1 byte, got %d", len(data))
+		}
+
+		var decoded Enum[Role]
+		if err := decoded.UnmarshalBinary(data); err != nil || decoded != role {
+			t.Errorf("expected %s, got %s (error %v)", role, decoded, err)
+		}
+	}
+
+	var invalid Enum[Role]
+	if _, err := invalid.MarshalBinary(); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	var decoded Enum[Role]
+	if err := decoded.UnmarshalBinary([]byte{42}); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected %v, got %v", ErrUnknownID, err)
+	}
+
+	for _, data := range [][]byte{nil, {0x80}, {0, 0}} {
+		if err := decoded.UnmarshalBinary(data); err == nil {
+			t.Errorf("expected error for %x, got nil", data)
+		}
+	}
+}
+
+func TestEnum_MarshalBinaryRange(t *testing.T) {
+	type smallEnum int8
+
+	negative := NewWithID[smallEnum]("Negative", -100)
+
+	data, err := negative.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var decoded Enum[smallEnum]
+	if err := decoded.UnmarshalBinary(data); err != nil || decoded != negative {
+		t.Errorf("expected %s, got %s (error %v)", negative, decoded, err)
+	}
+
+	// 300 as a varint does not fit in an int8.
+	if err := decoded.UnmarshalBinary([]byte{0xd8, 0x04}); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestEnum_GobRoundTrip(t *testing.T) {
+	type cached struct {
+		Role RoleEnum
+		Key  string
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cached{Admin, "key"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var decoded cached
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if decoded.Role != Admin || decoded.Key != "key" {
+		t.Errorf("expected {%s key}, got %+v", Admin, decoded)
+	}
+}
+
+func TestRegisterGob(t *testing.T) {
+	type payload struct {
+		Values []any
+	}
+
+	RegisterGob[Permission]()
+
+	var buf bytes.Buffer
+	values := []any{
+		Enum[Permission](Write),
+		NewEnumSet(Enum[Permission](Read)),
+		[]Enum[Permission]{Enum[Permission](Read), Enum[Permission](Write)},
+	}
+
+	if err := gob.NewEncoder(&buf).Encode(payload{values}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var decoded payload
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(decoded.Values) != 3 {
+		t.Fatalf("expected 3 values, got %d", len(decoded.Values))
+	}
+
+	if e, ok := decoded.Values[0].(Enum[Permission]); !ok || PermissionEnum(e) != Write {
+		t.Errorf("expected %s, got %v", Write, decoded.Values[0])
+	}
+
+	if s, ok := decoded.Values[1].(EnumSet[Permission]); !ok || !s.Has(Enum[Permission](Read)) || s.Len() != 1 {
+		t.Errorf("expected set with %s, got %v", Read, decoded.Values[1])
+	}
+
+	if s, ok := decoded.Values[2].([]Enum[Permission]); !ok || len(s) != 2 || PermissionEnum(s[0]) != Read || PermissionEnum(s[1]) != Write {
+		t.Errorf("expected [%s %s], got %v", Read, Write, decoded.Values[2])
+	}
+}
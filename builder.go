@@ -0,0 +1,81 @@
+package enum
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// EnumBuilder collects the attributes of an enum of type T to be registered
+// with Register. It is returned by Define and its methods can be chained:
+//
+//	var Admin = enum.Define[Role]("Admin").ID(10).Description("Full access").Register()
+type EnumBuilder[T constraints.Integer] struct {
+	name        string
+	id          T
+	hasID       bool
+	description string
+	label       string
+	aliases     []string
+	registered  bool
+}
+
+// Define returns a builder for an enum of type T with the given name. The
+// enum is only registered when Register is called.
+func Define[T constraints.Integer](name string) *EnumBuilder[T] {
+	return &EnumBuilder[T]{name: name}
+}
+
+// ID sets an explicit ID for the enum (see NewWithID). Without it, the ID is
+// auto-generated.
+func (b *EnumBuilder[T]) ID(id T) *EnumBuilder[T] {
+	b.id = id
+	b.hasID = true
+
+	return b
+}
+
+// Description sets the description of the enum (see NewWithDescription).
+func (b *EnumBuilder[T]) Description(description string) *EnumBuilder[T] {
+	b.description = description
+
+	return b
+}
+
+// Label sets the display label of the enum (see NewWithLabel).
+func (b *EnumBuilder[T]) Label(label string) *EnumBuilder[T] {
+	b.label = label
+
+	return b
+}
+
+// Alias adds aliases for the enum (see AddAlias).
+func (b *EnumBuilder[T]) Alias(aliases ...string) *EnumBuilder[T] {
+	b.aliases = append(b.aliases, aliases...)
+
+	return b
+}
+
+// Register registers the enum with all attributes set in the builder and
+// returns it. This panics if the enum can not be registered, with the same
+// rules as New, in which case nothing is registered. It also panics if called
+// more than once.
+func (b *EnumBuilder[T]) Register() Enum[T] {
+	if b.registered {
+		panic("enum " + b.name + " already registered by this builder")
+	}
+
+	opts := []Option[T]{
+		WithDescription[T](b.description),
+		WithLabel[T](b.label),
+		WithAliases[T](b.aliases...),
+	}
+
+	if b.hasID {
+		opts = append(opts, WithID(b.id))
+	}
+
+	e := New(b.name, opts...)
+
+	b.registered = true
+
+	return e
+}
@@ -0,0 +1,64 @@
+package enum
+
+import (
+	"testing"
+)
+
+func TestDefine(t *testing.T) {
+	type definedEnum int
+
+	zero := Define[definedEnum]("Zero").Register()
+	admin := Define[definedEnum]("Admin").
+		ID(10).
+		Description("Full access").
+		Label("Administrator").
+		Alias("Root", "Superuser").
+		Register()
+
+	if zero.ID() != 0 || zero.Description() != "" || zero.Label() != "Zero" {
+		t.Errorf("expected plain enum with ID 0, got %#v", zero)
+	}
+
+	if admin.ID() != 10 || admin.Description() != "Full access" || admin.Label() != "Administrator" {
+		t.Errorf("expected ID 10 with description and label, got %#v", admin)
+	}
+
+	for _, name := range []string{"Admin", "Root", "Superuser"} {
+		if e, err := EnumByTypeAndName[definedEnum](name); err != nil || e != admin {
+			t.Errorf("expected %s for %s, got %s (error %v)", admin, name, e, err)
+		}
+	}
+
+	// Nothing is registered if an alias is invalid.
+	for _, builder := range []*EnumBuilder[definedEnum]{
+		Define[definedEnum]("Guest").Alias("Root"),
+		Define[definedEnum]("Guest").Alias("Visitor", "Visitor"),
+		Define[definedEnum]("Guest").Alias("Guest"),
+		Define[definedEnum]("Guest").Alias(""),
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic, got normal execution")
+				}
+			}()
+
+			builder.Register()
+		}()
+
+		if IsValidName[definedEnum]("Guest") {
+			t.Fatalf("expected Guest to not be registered")
+		}
+	}
+
+	builder := Define[definedEnum]("User")
+	builder.Register()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	builder.Register()
+}
@@ -0,0 +1,54 @@
+// Command enumgen generates Go source declaring enums from a JSON spec file.
+// See enum.Generate for the spec format.
+//
+// Usage:
+//
+//	enumgen -in role.json -out role_enum.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bruno-ga/enum"
+)
+
+func main() {
+	in := flag.String("in", "", "path to the JSON spec file (defaults to stdin)")
+	out := flag.String("out", "", "path to the generated Go file (defaults to stdout)")
+	flag.Parse()
+
+	if err := run(*in, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "enumgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out string) error {
+	var spec io.Reader = os.Stdin
+	if in != "" {
+		f, err := os.Open(in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		spec = f
+	}
+
+	// Generate into a buffer so we never leave a partially written file.
+	var b bytes.Buffer
+	if err := enum.Generate(spec, &b); err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err := os.Stdout.Write(b.Bytes())
+		return err
+	}
+
+	return os.WriteFile(out, b.Bytes(), 0o644)
+}
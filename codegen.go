@@ -0,0 +1,342 @@
+package enum
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// GenerateMapLiteral returns the Go source for a variable declaration named
+// varName mapping the names of all enums associated with type T to their IDs,
+// in ID order. The output is gofmt-clean and uses the unqualified name of T as
+// the map value type, so it is meant to be embedded in generated code that
+// has access to that type. This panics if varName is not a valid identifier.
+func GenerateMapLiteral[T constraints.Integer](varName string) string {
+	if !token.IsIdentifier(varName) {
+		panic(fmt.Sprintf("invalid variable name %q", varName))
+	}
+
+	enums := EnumsByType[T]()
+	sort.Sort(ByID[T](enums))
+
+	var tInstance T
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "var %s = map[string]%s{\n", varName, reflect.TypeOf(tInstance).Name())
+	for _, e := range enums {
+		fmt.Fprintf(&b, "%q: %d,\n", e.Name(), e.ID())
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		panic(fmt.Sprintf("generated invalid source: %s", err))
+	}
+
+	return string(src)
+}
+
+// importPath is the import path used for this package in generated code.
+const importPath = "github.com/bruno-ga/enum"
+
+// generateSpec is the specification of an enum type consumed by Generate.
+type generateSpec struct {
+	Package string              `json:"package"`
+	Type    string              `json:"type"`
+	Base    string              `json:"base"`
+	Values  []generateSpecValue `json:"values"`
+}
+
+// generateSpecValue is the specification of a single enum consumed by
+// Generate.
+type generateSpecValue struct {
+	Name        string       `json:"name"`
+	ID          *json.Number `json:"id"`
+	Description string       `json:"description"`
+}
+
+// validBaseTypes are the integer types that can be used as base types in
+// specs consumed by Generate.
+var validBaseTypes = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"uintptr": true,
+}
+
+// parseSpecID parses the given spec ID as a value of the given base type and
+// returns its canonical decimal form. It returns a non-nil error if the ID is
+// not an integer in the range of the type.
+func parseSpecID(base string, id json.Number) (string, error) {
+	// Sized types end with their bit size. Others are assumed to be 64 bits
+	// wide, as architecture-dependent sizes are not known here.
+	bitSize := 64
+	if n, err := strconv.Atoi(strings.TrimLeft(base, "intu")); err == nil {
+		bitSize = n
+	}
+
+	if strings.HasPrefix(base, "u") {
+		v, err := strconv.ParseUint(string(id), 10, bitSize)
+		if err != nil {
+			return "", fmt.Errorf("ID %s is not a valid %s", id, base)
+		}
+
+		return strconv.FormatUint(v, 10), nil
+	}
+
+	v, err := strconv.ParseInt(string(id), 10, bitSize)
+	if err != nil {
+		return "", fmt.Errorf("ID %s is not a valid %s", id, base)
+	}
+
+	return strconv.FormatInt(v, 10), nil
+}
+
+// Generate reads a JSON enum specification from spec and writes to w the Go
+// source of a file declaring the enum type and one package-level Enum
+// variable per value. The specification has the following format:
+//
+//	{
+//	  "package": "accounts",
+//	  "type": "Role",
+//	  "base": "int",
+//	  "values": [
+//	    {"name": "Admin", "id": 1, "description": "Can do anything."}
+//	  ]
+//	}
+//
+// The base integer type is optional and defaults to int. Value IDs and
+// descriptions are optional and, when given, the generated code passes them
+// to New with WithID and WithDescription. IDs must be in the range of the
+// base type. Variables are named by concatenating the type and value names,
+// so both must form a valid Go identifier.
+func Generate(spec io.Reader, w io.Writer) error {
+	d := json.NewDecoder(spec)
+	d.DisallowUnknownFields()
+
+	var s generateSpec
+	if err := d.Decode(&s); err != nil {
+		return fmt.Errorf("invalid spec: %w", err)
+	}
+
+	if s.Base == "" {
+		s.Base = "int"
+	}
+
+	if !token.IsIdentifier(s.Package) {
+		return fmt.Errorf("invalid package name %q", s.Package)
+	}
+
+	if !token.IsIdentifier(s.Type) {
+		return fmt.Errorf("invalid type name %q", s.Type)
+	}
+
+	if !validBaseTypes[s.Base] {
+		return fmt.Errorf("invalid base type %q", s.Base)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by enumgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", s.Package)
+	fmt.Fprintf(&b, "import %q\n\n", importPath)
+	fmt.Fprintf(&b, "type %s %s\n\n", s.Type, s.Base)
+	b.WriteString("var (\n")
+
+	// Auto-generated IDs are assigned in declaration order, skipping values
+	// with explicit IDs, so collisions can be detected here instead of
+	// panicking during package initialization.
+	var nextID uint64
+
+	names := make(map[string]bool, len(s.Values))
+	ids := make(map[string]string, len(s.Values))
+	for i, v := range s.Values {
+		varName := s.Type + v.Name
+		if v.Name == "" || !token.IsIdentifier(varName) {
+			return fmt.Errorf("invalid name %q for value at index %d", v.Name, i)
+		}
+
+		if names[v.Name] {
+			return fmt.Errorf("duplicate name %q for value at index %d", v.Name, i)
+		}
+
+		names[v.Name] = true
+
+		if v.Description != "" {
+			for _, line := range strings.Split(v.Description, "\n") {
+				fmt.Fprintf(&b, "// %s\n", line)
+			}
+		}
+
+		specID := json.Number(strconv.FormatUint(nextID, 10))
+		if v.ID != nil {
+			specID = *v.ID
+		} else {
+			nextID++
+		}
+
+		id, err := parseSpecID(s.Base, specID)
+		if err != nil {
+			return fmt.Errorf("invalid ID for value at index %d: %w", i, err)
+		}
+
+		if other, ok := ids[id]; ok {
+			return fmt.Errorf("duplicate ID %s for value at index %d, already used by %s", id, i, other)
+		}
+
+		ids[id] = v.Name
+
+		fmt.Fprintf(&b, "%s = enum.New[%s](%q", varName, s.Type, v.Name)
+
+		if v.ID != nil {
+			fmt.Fprintf(&b, ", enum.WithID[%s](%s)", s.Type, id)
+		}
+
+		if v.Description != "" {
+			fmt.Fprintf(&b, ", enum.WithDescription[%s](%q)", s.Type, v.Description)
+		}
+
+		b.WriteString(")\n")
+	}
+
+	b.WriteString(")\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("generated invalid source: %w", err)
+	}
+
+	_, err = w.Write(src)
+
+	return err
+}
+
+// JSONSchema returns a JSON Schema fragment describing how enums of type T are
+// encoded in JSON according to their EncodingMode. For EncodeName (the
+// default) this is {"type":"string","enum":[...]} with all enum names in ID
+// order, for EncodeID it is the integer equivalent with all IDs and, for
+// EncodeObject, it is an object schema with both. Names are transformed as
+// set with SetJSONNameTransform. If no enums are associated with T (even if
+// it was configured with SetEncoding, Freeze, etc), the returned error wraps
+// ErrTypeNotRegistered, as an empty "enum" array is not a valid schema.
+func JSONSchema[T constraints.Integer]() ([]byte, error) {
+	s := getSetForType[T]()
+	if s == nil {
+		return nil, &LookupError{TypeName: getTypeName[T](), Err: ErrTypeNotRegistered}
+	}
+
+	enums := s.Ordered()
+	if len(enums) == 0 {
+		return nil, &LookupError{TypeName: getTypeName[T](), Err: ErrTypeNotRegistered}
+	}
+
+	// IDs are collected as numbers so byte-sized types are not marshalled as
+	// base64 strings.
+	ids := make([]json.Number, 0, len(enums))
+	names := make([]string, 0, len(enums))
+	for _, e := range enums {
+		ids = append(ids, json.Number(fmt.Sprint(e.id)))
+		names = append(names, toJSONName[T](e.name))
+	}
+
+	type schema struct {
+		Type       string            `json:"type"`
+		Enum       any               `json:"enum,omitempty"`
+		Properties map[string]schema `json:"properties,omitempty"`
+		Required   []string          `json:"required,omitempty"`
+	}
+
+	nameSchema := schema{Type: "string", Enum: names}
+	idSchema := schema{Type: "integer", Enum: ids}
+
+	switch s.encoding {
+	case EncodeID:
+		return json.Marshal(idSchema)
+	case EncodeObject:
+		return json.Marshal(schema{
+			Type:       "object",
+			Properties: map[string]schema{"id": idSchema, "name": nameSchema},
+			Required:   []string{"name"},
+		})
+	default:
+		return json.Marshal(nameSchema)
+	}
+}
+
+// GraphQLEnum returns the GraphQL SDL definition of an enum type named gqlName
+// with the names of all enums associated with type T as values, in ID order.
+// Names are uppercased unless a different transform is set for T with
+// SetGraphQLNameTransform. If no enums are associated with T or a transformed
+// name is not a valid or unique GraphQL enum value, a non-nil error is
+// returned.
+func GraphQLEnum[T constraints.Integer](gqlName string) (string, error) {
+	s := getSetForType[T]()
+	if s == nil {
+		return "", &LookupError{TypeName: getTypeName[T](), Err: ErrTypeNotRegistered}
+	}
+
+	if !isGraphQLName(gqlName) {
+		return "", fmt.Errorf("invalid GraphQL type name %q", gqlName)
+	}
+
+	transform := s.graphQLNameTransform
+	if transform == nil {
+		transform = strings.ToUpper
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "enum %s {\n", gqlName)
+
+	seen := make(map[string]string)
+	for _, name := range s.Names() {
+		value := transform(name)
+
+		if !isGraphQLName(value) || value == "true" || value == "false" || value == "null" {
+			return "", fmt.Errorf("invalid GraphQL enum value %q for %s", value, name)
+		}
+
+		if other, ok := seen[value]; ok {
+			return "", fmt.Errorf("GraphQL enum value %q used by both %s and %s", value, other, name)
+		}
+
+		seen[value] = name
+
+		fmt.Fprintf(&b, "  %s\n", value)
+	}
+
+	b.WriteString("}\n")
+
+	return b.String(), nil
+}
+
+// SetGraphQLNameTransform sets the function used by GraphQLEnum to convert
+// names of enums of type T to GraphQL enum values. Passing nil restores the
+// default (strings.ToUpper).
+func SetGraphQLNameTransform[T constraints.Integer](transform func(name string) string) {
+	getOrCreateSetForType[T]().graphQLNameTransform = transform
+}
+
+// isGraphQLName returns true if name matches /[_A-Za-z][_0-9A-Za-z]*/.
+func isGraphQLName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
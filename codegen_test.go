@@ -0,0 +1,208 @@
+package enum
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestGenerateMapLiteral(t *testing.T) {
+	expected := `var permissions = map[string]Permission{
+	"Unknown": 0,
+	"Read":    1,
+	"Write":   2,
+}
+`
+
+	if s := GenerateMapLiteral[Permission]("permissions"); s != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, s)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	spec := `{
+		"package": "accounts",
+		"type": "Role",
+		"values": [
+			{"name": "Unknown"},
+			{"name": "Admin", "description": "Can do anything."},
+			{"name": "Legacy", "id": 10},
+			{"name": "Guest", "id": 20, "description": "Read only."}
+		]
+	}`
+
+	expected := `// Code generated by enumgen. DO NOT EDIT.
+
+package accounts
+
+import "github.com/bruno-ga/enum"
+
+type Role int
+
+var (
+	RoleUnknown = enum.New[Role]("Unknown")
+	// Can do anything.
+	RoleAdmin  = enum.New[Role]("Admin", enum.WithDescription[Role]("Can do anything."))
+	RoleLegacy = enum.New[Role]("Legacy", enum.WithID[Role](10))
+	// Read only.
+	RoleGuest = enum.New[Role]("Guest", enum.WithID[Role](20), enum.WithDescription[Role]("Read only."))
+)
+`
+
+	var b strings.Builder
+	if err := Generate(strings.NewReader(spec), &b); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestGenerate_IDRange(t *testing.T) {
+	spec := `{"package": "p", "type": "Big", "base": "uint64", "values": [{"name": "Max", "id": 18446744073709551615}]}`
+
+	var b strings.Builder
+	if err := Generate(strings.NewReader(spec), &b); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := `enum.WithID[Big](18446744073709551615)`; !strings.Contains(b.String(), expected) {
+		t.Errorf("expected output to contain %s, got %s", expected, b.String())
+	}
+}
+
+func TestGenerate_InvalidSpec(t *testing.T) {
+	specs := []string{
+		`{"package": "accounts", "type": "Role", "values": [{"name": "Not Valid"}]}`,
+		`{"package": "accounts", "type": "Role", "values": [{"name": "A"}, {"name": "A"}]}`,
+		`{"package": "accounts", "type": "Role", "base": "string"}`,
+		`{"package": "accounts", "type": "Role", "unknown": true}`,
+		`{"package": "accounts", "type": "Role", "base": "uint8", "values": [{"name": "A", "id": 300}]}`,
+		`{"package": "accounts", "type": "Role", "base": "uint", "values": [{"name": "A", "id": -1}]}`,
+		`{"package": "accounts", "type": "Role", "base": "int64", "values": [{"name": "A", "id": 18446744073709551615}]}`,
+		`{"package": "accounts", "type": "Role", "values": [{"name": "A", "id": 1.5}]}`,
+		`{"package": "accounts", "type": "Role", "values": [{"name": "A", "id": 1}, {"name": "B", "id": 1}]}`,
+		`{"package": "accounts", "type": "Role", "values": [{"name": "A"}, {"name": "B", "id": 0}]}`,
+		`{"package": "accounts", "type": "Role", "values": [{"name": "A", "id": 1}, {"name": "B"}, {"name": "C"}]}`,
+	}
+
+	for _, spec := range specs {
+		if err := Generate(strings.NewReader(spec), io.Discard); err == nil {
+			t.Errorf("expected error for %s, got nil", spec)
+		}
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema, err := JSONSchema[Permission]()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"type":"string","enum":["Unknown","Read","Write"]}`
+	if string(schema) != expected {
+		t.Errorf("expected %s, got %s", expected, schema)
+	}
+
+	type unregisteredEnum int
+
+	if _, err := JSONSchema[unregisteredEnum](); !errors.Is(err, ErrTypeNotRegistered) {
+		t.Errorf("expected ErrTypeNotRegistered, got %v", err)
+	}
+
+	type configuredSchemaEnum int
+
+	SetEncoding[configuredSchemaEnum](EncodeID)
+
+	if schema, err := JSONSchema[configuredSchemaEnum](); !errors.Is(err, ErrTypeNotRegistered) {
+		t.Errorf("expected ErrTypeNotRegistered, got %s (error %v)", schema, err)
+	}
+}
+
+func TestJSONSchema_Uint8(t *testing.T) {
+	type byteSchemaEnum uint8
+
+	New[byteSchemaEnum]("Zero")
+	New[byteSchemaEnum]("One")
+
+	SetEncoding[byteSchemaEnum](EncodeID)
+
+	schema, err := JSONSchema[byteSchemaEnum]()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := `{"type":"integer","enum":[0,1]}`; string(schema) != expected {
+		t.Errorf("expected %s, got %s", expected, schema)
+	}
+
+	SetEncoding[byteSchemaEnum](EncodeObject)
+
+	schema, err = JSONSchema[byteSchemaEnum]()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.Contains(string(schema), `"id":{"type":"integer","enum":[0,1]}`) {
+		t.Errorf("expected integer IDs in %s", schema)
+	}
+}
+
+func TestGraphQLEnum(t *testing.T) {
+	sdl, err := GraphQLEnum[Permission]("Permission")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "enum Permission {\n  UNKNOWN\n  READ\n  WRITE\n}\n"
+	if sdl != expected {
+		t.Errorf("expected %q, got %q", expected, sdl)
+	}
+
+	if _, err := GraphQLEnum[Permission]("Not Valid"); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	type unregisteredEnum int
+
+	if _, err := GraphQLEnum[unregisteredEnum]("Unregistered"); !errors.Is(err, ErrTypeNotRegistered) {
+		t.Errorf("expected ErrTypeNotRegistered, got %v", err)
+	}
+}
+
+func TestSetGraphQLNameTransform(t *testing.T) {
+	type gqlEnum int
+
+	New[gqlEnum]("first-value")
+	New[gqlEnum]("First_Value")
+
+	SetGraphQLNameTransform[gqlEnum](func(name string) string {
+		return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+	})
+
+	if _, err := GraphQLEnum[gqlEnum]("Values"); err == nil {
+		t.Errorf("expected duplicate value error, got nil")
+	}
+
+	SetGraphQLNameTransform[gqlEnum](func(name string) string {
+		return strings.ReplaceAll(name, "-", "_")
+	})
+
+	sdl, err := GraphQLEnum[gqlEnum]("Values")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "enum Values {\n  first_value\n  First_Value\n}\n"
+	if sdl != expected {
+		t.Errorf("expected %q, got %q", expected, sdl)
+	}
+
+	SetGraphQLNameTransform[gqlEnum](nil)
+
+	if _, err := GraphQLEnum[gqlEnum]("Values"); err == nil {
+		t.Errorf("expected invalid value error, got nil")
+	}
+}
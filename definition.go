@@ -0,0 +1,69 @@
+package enum
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// EnumDefinition is the full definition of an enum, as returned by Definition
+// and Definitions. It is intended for tooling that reconstructs enums, like
+// documentation generators and admin interfaces.
+type EnumDefinition struct {
+	// Name is the canonical name of the enum.
+	Name string `json:"name"`
+
+	// ID is the ID of the enum, with the enum type T as its dynamic type.
+	ID any `json:"id"`
+
+	// Description is the description of the enum, if any.
+	Description string `json:"description,omitempty"`
+
+	// Label is the display label of the enum. Contrary to Label, it is empty
+	// if no label was set.
+	Label string `json:"label,omitempty"`
+
+	// Aliases are the additional names of the enum, in the order they were
+	// added.
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Deprecated is true if the enum was deprecated.
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+// Definition returns the full definition of this Enum instance.
+func (e internalEnumWrapper[T]) Definition() EnumDefinition {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	var aliases []string
+	if s := getSetForType[T](); s != nil {
+		aliases = s.Aliases(e.internalEnum)
+	}
+
+	return EnumDefinition{
+		Name:        e.internalEnum.name,
+		ID:          e.internalEnum.id,
+		Description: e.internalEnum.description,
+		Label:       e.internalEnum.label,
+		Aliases:     aliases,
+		Deprecated:  e.IsDeprecated(),
+	}
+}
+
+// Definitions returns the full definitions of all enums associated with type
+// T, in ID order. If there are no such enums, it returns an empty slice.
+func Definitions[T constraints.Integer]() []EnumDefinition {
+	s := getSetForType[T]()
+	if s == nil {
+		return []EnumDefinition{}
+	}
+
+	enums := s.Enums()
+
+	definitions := make([]EnumDefinition, 0, len(enums))
+	for _, e := range enums {
+		definitions = append(definitions, e.Definition())
+	}
+
+	return definitions
+}
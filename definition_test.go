@@ -0,0 +1,49 @@
+package enum
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEnum_Definition(t *testing.T) {
+	type definitionEnum int
+
+	second := New[definitionEnum]("Second", WithID[definitionEnum](2), WithDescription[definitionEnum]("The second one."))
+	first := New[definitionEnum]("First", WithID[definitionEnum](1), WithLabel[definitionEnum]("1st"), WithAliases[definitionEnum]("One"))
+	first.AddAlias("Uno")
+	second.Deprecate()
+
+	expected := EnumDefinition{
+		Name:    "First",
+		ID:      definitionEnum(1),
+		Label:   "1st",
+		Aliases: []string{"One", "Uno"},
+	}
+
+	if d := first.Definition(); !reflect.DeepEqual(d, expected) {
+		t.Errorf("expected %+v, got %+v", expected, d)
+	}
+
+	definitions := Definitions[definitionEnum]()
+	if len(definitions) != 2 || definitions[0].Name != "First" || definitions[1].Name != "Second" {
+		t.Fatalf("expected definitions of First and Second, got %+v", definitions)
+	}
+
+	data, err := json.Marshal(definitions[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if s := `{"name":"Second","id":2,"description":"The second one.","deprecated":true}`; string(data) != s {
+		t.Errorf("expected %s, got %s", s, data)
+	}
+}
+
+func TestDefinitions_Unregistered(t *testing.T) {
+	type unregisteredDefinitionEnum int
+
+	if definitions := Definitions[unregisteredDefinitionEnum](); definitions == nil || len(definitions) != 0 {
+		t.Errorf("expected empty slice, got %#v", definitions)
+	}
+}
@@ -0,0 +1,163 @@
+package enum
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// EncodingMode determines how enums of a specific type are marshalled.
+type EncodingMode int
+
+const (
+	// EncodeName encodes enums as their names. This is the default.
+	EncodeName EncodingMode = iota
+
+	// EncodeID encodes enums as their numeric IDs, both in JSON and as text
+	// (decimal).
+	EncodeID
+
+	// EncodeObject encodes enums as JSON objects with both the ID and the
+	// name, like {"id":1,"name":"Admin"}. Text still uses names.
+	EncodeObject
+)
+
+// SetEncoding sets the encoding mode used by MarshalJSON and MarshalText for
+// enums of type T. Independently of the mode, UnmarshalJSON accepts all
+// encodings and UnmarshalText accepts names. With EncodeID, UnmarshalText
+// resolves numeric text as an ID before trying names, so, contrary to
+// SetNumericText, an ID takes precedence over a numeric name.
+func SetEncoding[T constraints.Integer](mode EncodingMode) {
+	getOrCreateSetForType[T]().encoding = mode
+}
+
+// getEncoding returns the encoding mode for enums of type T.
+func getEncoding[T constraints.Integer]() EncodingMode {
+	s := getSetForType[T]()
+	if s == nil {
+		return EncodeName
+	}
+
+	return s.encoding
+}
+
+// SetJSONNameTransform sets functions to convert names of enums of type T to
+// and from their JSON representation, for JSON APIs using a different naming
+// convention (like "ADMIN" for "Admin"). MarshalJSON applies to to names and
+// UnmarshalJSON applies from to names before looking them up. Either can be
+// nil, which means names are used unchanged in that direction. Other
+// encodings (text, SQL, etc) always use the canonical names.
+func SetJSONNameTransform[T constraints.Integer](to func(string) string, from func(string) string) {
+	s := getOrCreateSetForType[T]()
+
+	s.jsonNameTo = to
+	s.jsonNameFrom = from
+}
+
+// toJSONName returns the JSON representation of the given name of an enum of
+// type T.
+func toJSONName[T constraints.Integer](name string) string {
+	if s := getSetForType[T](); s != nil && s.jsonNameTo != nil {
+		return s.jsonNameTo(name)
+	}
+
+	return name
+}
+
+// fromJSONName returns the name of an enum of type T for the given JSON
+// representation.
+func fromJSONName[T constraints.Integer](name string) string {
+	if s := getSetForType[T](); s != nil && s.jsonNameFrom != nil {
+		return s.jsonNameFrom(name)
+	}
+
+	return name
+}
+
+// jsonObject is the representation of an enum in the EncodeObject mode.
+type jsonObject struct {
+	ID   *json.Number `json:"id,omitempty"`
+	Name *string      `json:"name,omitempty"`
+}
+
+// marshalJSONObject returns the EncodeObject representation of the given enum.
+func marshalJSONObject[T constraints.Integer](e *internalEnum[T]) ([]byte, error) {
+	id := json.Number(fmt.Sprintf("%d", e.id))
+	name := toJSONName[T](e.name)
+
+	return json.Marshal(jsonObject{&id, &name})
+}
+
+// unmarshalJSONObject returns the enum for the given EncodeObject
+// representation. The enum is resolved by name and, if an ID is also present,
+// it must match the ID of the resolved enum. Without a name, the enum is
+// resolved by ID. Keys other than id and name are rejected.
+func unmarshalJSONObject[T constraints.Integer](data []byte) (*internalEnum[T], error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var object jsonObject
+	if err := decoder.Decode(&object); err != nil {
+		if keys := unexpectedJSONObjectKeys(data); len(keys) > 0 {
+			return nil, fmt.Errorf("unexpected keys %s in enum object %s", strings.Join(keys, ", "), data)
+		}
+
+		return nil, fmt.Errorf("source should be an object with id and name, got %s", data)
+	}
+
+	var id T
+	if object.ID != nil {
+		var err error
+		if id, err = parseID[T](object.ID.String()); err != nil {
+			return nil, fmt.Errorf("invalid enum ID %s: %w", object.ID, err)
+		}
+	}
+
+	if object.Name == nil {
+		if object.ID == nil {
+			return nil, fmt.Errorf("source should be an object with id and name, got %s", data)
+		}
+
+		return getInternalEnumForID(id)
+	}
+
+	name := fromJSONName[T](*object.Name)
+
+	e, err := getInternalEnumForName[T](name)
+	if err != nil {
+		// Unknown names resolve to the fallback, if any, regardless of the ID.
+		return getInternalEnumForDecoding[T](name)
+	}
+
+	if object.ID != nil && e.id != id {
+		return nil, fmt.Errorf("id %d does not match name %s (id %d) for type %s", id, name, e.id, getTypeName[T]())
+	}
+
+	return e, nil
+}
+
+// unexpectedJSONObjectKeys returns the quoted keys of the given JSON object
+// that do not correspond to jsonObject fields, in sorted order. As with
+// encoding/json, keys are matched case-insensitively.
+func unexpectedJSONObjectKeys(data []byte) []string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	var keys []string
+	for key := range fields {
+		if !strings.EqualFold(key, "id") && !strings.EqualFold(key, "name") {
+			keys = append(keys, strconv.Quote(key))
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
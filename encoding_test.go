@@ -0,0 +1,224 @@
+package enum
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncodeID(t *testing.T) {
+	type idEncodedEnum int
+
+	New[idEncodedEnum]("Zero")
+	one := New[idEncodedEnum]("One")
+
+	SetEncoding[idEncodedEnum](EncodeID)
+
+	data, err := json.Marshal(one)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if string(data) != `1` {
+		t.Errorf("expected 1, got %s", data)
+	}
+
+	var e Enum[idEncodedEnum]
+	if err := json.Unmarshal(data, &e); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if e != one {
+		t.Errorf("expected %s, got %s", one, e)
+	}
+}
+
+func TestEncodeObject(t *testing.T) {
+	type objectEncodedEnum int
+
+	New[objectEncodedEnum]("Zero")
+	one := New[objectEncodedEnum]("One")
+
+	SetEncoding[objectEncodedEnum](EncodeObject)
+
+	data, err := json.Marshal(one)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if string(data) != `{"id":1,"name":"One"}` {
+		t.Errorf(`expected {"id":1,"name":"One"}, got %s`, data)
+	}
+
+	for _, input := range []string{`{"id":1,"name":"One"}`, `{"name":"One"}`, `{"id":1}`, `"One"`} {
+		var e Enum[objectEncodedEnum]
+		if err := json.Unmarshal([]byte(input), &e); err != nil {
+			t.Fatalf("unexpected error for %s: %s", input, err)
+		}
+
+		if e != one {
+			t.Errorf("expected %s for %s, got %s", one, input, e)
+		}
+	}
+
+	for _, input := range []string{`{"id":0,"name":"One"}`, `{}`, `{"name":"Two"}`} {
+		var e Enum[objectEncodedEnum]
+		if err := json.Unmarshal([]byte(input), &e); err == nil {
+			t.Errorf("expected error for %s, got nil", input)
+		}
+	}
+}
+
+func TestEncodeObject_UnknownKeys(t *testing.T) {
+	type strictObjectEnum int
+
+	one := New[strictObjectEnum]("One")
+
+	var e Enum[strictObjectEnum]
+
+	err := json.Unmarshal([]byte(`{"id":0,"name":"One","nmae":"One","extra":true}`), &e)
+	if err == nil || !strings.Contains(err.Error(), `unexpected keys "extra", "nmae"`) {
+		t.Errorf("expected unexpected keys error, got %v", err)
+	}
+
+	// Like encoding/json, known keys are matched case-insensitively.
+	if err := json.Unmarshal([]byte(`{"ID":0,"Name":"One"}`), &e); err != nil || e != one {
+		t.Errorf("expected %s, got %s (error %v)", one, e, err)
+	}
+}
+
+func TestEncodingJSONSchema(t *testing.T) {
+	type schemaEnum int
+
+	New[schemaEnum]("Zero")
+	New[schemaEnum]("One")
+
+	SetEncoding[schemaEnum](EncodeID)
+
+	schema, err := JSONSchema[schemaEnum]()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if string(schema) != `{"type":"integer","enum":[0,1]}` {
+		t.Errorf(`expected {"type":"integer","enum":[0,1]}, got %s`, schema)
+	}
+
+	SetEncoding[schemaEnum](EncodeObject)
+
+	schema, err = JSONSchema[schemaEnum]()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"type":"object","properties":{"id":{"type":"integer","enum":[0,1]},"name":{"type":"string","enum":["Zero","One"]}},"required":["name"]}`
+	if string(schema) != expected {
+		t.Errorf("expected %s, got %s", expected, schema)
+	}
+}
+
+func TestEncodeIDText(t *testing.T) {
+	type textEncodedEnum int
+
+	New[textEncodedEnum]("Zero")
+	one := New[textEncodedEnum]("One")
+	ten := NewWithID[textEncodedEnum]("10", 10)
+
+	for _, mode := range []EncodingMode{EncodeName, EncodeID} {
+		SetEncoding[textEncodedEnum](mode)
+
+		expected := "One"
+		if mode == EncodeID {
+			expected = "1"
+		}
+
+		text, err := one.MarshalText()
+		if err != nil || string(text) != expected {
+			t.Errorf("expected %s, got %s (error %v)", expected, text, err)
+		}
+
+		var e Enum[textEncodedEnum]
+		if err := e.UnmarshalText(text); err != nil || e != one {
+			t.Errorf("expected %s, got %s (error %v)", one, e, err)
+		}
+
+		// Names are accepted in both modes.
+		if err := e.UnmarshalText([]byte("One")); err != nil || e != one {
+			t.Errorf("expected %s, got %s (error %v)", one, e, err)
+		}
+	}
+
+	// In EncodeID mode, numeric text is an ID even if it is also a name.
+	var e Enum[textEncodedEnum]
+	if err := e.UnmarshalText([]byte("10")); err != nil || e != ten {
+		t.Errorf("expected %s, got %s (error %v)", ten, e, err)
+	}
+
+	if err := e.UnmarshalText([]byte("42")); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected %v, got %v", ErrUnknownID, err)
+	}
+}
+
+func TestSetJSONNameTransform(t *testing.T) {
+	type screamingEnum int
+
+	New[screamingEnum]("Unknown")
+	superAdmin := New[screamingEnum]("SuperAdmin")
+
+	toScreaming := func(name string) string {
+		var b strings.Builder
+		for i, r := range name {
+			if i > 0 && r >= 'A' && r <= 'Z' {
+				b.WriteByte('_')
+			}
+
+			b.WriteRune(r)
+		}
+
+		return strings.ToUpper(b.String())
+	}
+
+	fromScreaming := func(name string) string {
+		var b strings.Builder
+		for _, word := range strings.Split(name, "_") {
+			if word != "" {
+				b.WriteString(word[:1] + strings.ToLower(word[1:]))
+			}
+		}
+
+		return b.String()
+	}
+
+	SetJSONNameTransform[screamingEnum](toScreaming, fromScreaming)
+
+	for _, mode := range []EncodingMode{EncodeName, EncodeObject} {
+		SetEncoding[screamingEnum](mode)
+
+		data, err := json.Marshal(superAdmin)
+		if err != nil || !strings.Contains(string(data), `"SUPER_ADMIN"`) {
+			t.Errorf("expected SUPER_ADMIN, got %s (error %v)", data, err)
+		}
+
+		var e Enum[screamingEnum]
+		if err := json.Unmarshal(data, &e); err != nil || e != superAdmin {
+			t.Errorf("expected %s, got %s (error %v)", superAdmin, e, err)
+		}
+	}
+
+	if schema, err := JSONSchema[screamingEnum](); err != nil || !strings.Contains(string(schema), `"SUPER_ADMIN"`) {
+		t.Errorf("expected schema with SUPER_ADMIN, got %s (error %v)", schema, err)
+	}
+
+	// The canonical name is unchanged.
+	if text, _ := superAdmin.MarshalText(); string(text) != "SuperAdmin" {
+		t.Errorf("expected SuperAdmin, got %s", text)
+	}
+
+	SetJSONNameTransform[screamingEnum](nil, nil)
+	SetEncoding[screamingEnum](EncodeName)
+
+	if data, err := json.Marshal(superAdmin); err != nil || string(data) != `"SuperAdmin"` {
+		t.Errorf("expected \"SuperAdmin\", got %s (error %v)", data, err)
+	}
+}
@@ -1,48 +1,108 @@
 package enum
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"go/token"
+	"io"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	"golang.org/x/exp/constraints"
 )
 
-// Enum represents a named Enum that is associaterd with an ID. Enum IDs
-// are auto-generated starting from 0 and monotonically increasing in
-// declaration order. The zero value of an Enum is not valid. It is safe
-// to use this type to create other types (type OtherType Enum[MyEnumType]) as
-// it does not implement any methods itself and, instead, delegates all
-// methods to embedded types.
+// Enum represents a named Enum that is associaterd with an ID. Unless given
+// explicitly, Enum IDs are auto-generated starting from 0 and monotonically
+// increasing in declaration order. The zero value of an Enum is not valid.
+// It is safe to use this type to create other types (type OtherType
+// Enum[MyEnumType]) as it does not implement any methods itself and, instead,
+// delegates all methods to embedded types.
 type Enum[T constraints.Integer] struct {
 	// As internalEnumWrapper is not a pointer, it will never be nil so we use
 	// it to implement all methods that we need.
 	internalEnumWrapper[T]
 }
 
-// We need to use any here because each set will have a different type. This is
-// ok though as we will always know the exact type stored and will always
-// expose it as the actual type.
-var setByTypeName = make(map[string]any)
+// We need to use an interface here because each set will have a different
+// type. This is ok though as we will always know the exact type stored and
+// will always expose it as the actual type. The interface only exposes the
+// methods we need to handle sets without knowing their types.
+//
+// Sets are keyed by reflect.Type instead of by type name as names are not
+// unique for types declared inside function bodies.
+var setByType = make(map[reflect.Type]untypedSet)
 
-// getTypeName returns the unique name of the associated type T.
+// setByTypeMu guards setByType.
+var setByTypeMu sync.RWMutex
+
+// getType returns the reflect.Type associated with type T.
+func getType[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// typeNameByType caches type names computed by getTypeName as they are needed
+// in hot paths (lookups, formatting, errors).
+var typeNameByType sync.Map // map[reflect.Type]string
+
+// getTypeName returns the fully qualified name of the associated type T. Note
+// that different types declared inside function bodies might share the same
+// name.
 func getTypeName[T any]() string {
-	var tInstance T
+	tType := getType[T]()
+
+	if typeName, ok := typeNameByType.Load(tType); ok {
+		return typeName.(string)
+	}
+
+	var typeName string
+	if tType.PkgPath() == "" {
+		// Predeclared type.
+		typeName = tType.String()
+	} else {
+		typeName = tType.PkgPath() + "." + tType.Name()
+	}
+
+	typeNameByType.Store(tType, typeName)
+
+	return typeName
+}
 
-	tType := reflect.TypeOf(tInstance)
+// getSetForType returns the set associated with the type T or nil if no enums
+// were ever registered for it.
+func getSetForType[T constraints.Integer]() *internalSet[T] {
+	setByTypeMu.RLock()
+	defer setByTypeMu.RUnlock()
+
+	as, ok := setByType[getType[T]()]
+	if !ok {
+		return nil
+	}
 
-	return tType.PkgPath() + "." + tType.Name()
+	return as.(*internalSet[T])
 }
 
 func getOrCreateSetForType[T constraints.Integer]() *internalSet[T] {
-	typeName := getTypeName[T]()
+	tType := getType[T]()
+
+	setByTypeMu.Lock()
+	defer setByTypeMu.Unlock()
 
 	var s *internalSet[T]
 
-	if as, ok := setByTypeName[typeName]; !ok {
+	if as, ok := setByType[tType]; !ok {
+		if tType.Name() == "" {
+			panic(fmt.Sprintf("enum type %s must be a named type", tType))
+		}
+
 		s = newInternalSet[T]()
-		setByTypeName[typeName] = s
+		setByType[tType] = s
 	} else {
 		s = as.(*internalSet[T])
 	}
@@ -50,33 +110,375 @@ func getOrCreateSetForType[T constraints.Integer]() *internalSet[T] {
 	return s
 }
 
-// New returns a new Enum associated with the given name and type T.
-func New[T constraints.Integer](name string) Enum[T] {
+// New returns a new Enum associated with the given name and type T, configured
+// by the given options (WithID, WithDescription, WithLabel and WithAliases).
+// This panics if the enum can not be registered, in which case nothing is
+// registered.
+func New[T constraints.Integer](name string, opts ...Option[T]) Enum[T] {
 	if name == "" {
 		panic("enum name cannot be empty")
 	}
 
 	s := getOrCreateSetForType[T]()
 
-	return Enum[T]{internalEnumWrapper[T]{s.Add(name)}}
+	return Enum[T]{internalEnumWrapper[T]{s.Add(name, resolveOptions(opts))}}
 }
 
-// EnumsByType returns all enums associated with the given type T.
-func EnumsByType[T constraints.Integer]() []Enum[T] {
-	s := setByTypeName[getTypeName[T]()]
+// Reserve presizes the enum set associated with type T for n enums, reducing
+// allocations when registering many enums. It must be called before any enum
+// of type T is registered, otherwise it panics.
+func Reserve[T constraints.Integer](n int) {
+	getOrCreateSetForType[T]().Reserve(n)
+}
+
+// GetOrNew is like New but, if an enum with the given name is already
+// associated with type T, returns it instead of panicking. This allows
+// idempotent initialization, for example when the same declarations run more
+// than once. It still panics if the existing enum was declared differently
+// (with an explicit ID, a description, a label or aliases) or if the name is
+// used as an alias.
+func GetOrNew[T constraints.Integer](name string) Enum[T] {
+	if name == "" {
+		panic("enum name cannot be empty")
+	}
+
+	s := getOrCreateSetForType[T]()
+
+	e := s.GetOrAdd(name)
+	if e.explicitID || e.description != "" || e.label != "" || e.declaredAliases {
+		panic(fmt.Sprintf("enum %s already exists with a different declaration", name))
+	}
+
+	return Enum[T]{internalEnumWrapper[T]{e}}
+}
 
-	nameEnumMap := s.(*internalSet[T]).nameEnumMap
+// NewWithID returns a new Enum associated with the given name, explicit ID and
+// type T. Any value of T is accepted as ID, including negative ones for signed
+// types. Explicit IDs do not count towards the auto-generated ID capacity of
+// the type. This panics if the name or the ID are already in use by another
+// enum of the same type.
+func NewWithID[T constraints.Integer](name string, id T) Enum[T] {
+	return New(name, WithID(id))
+}
+
+// WithUnknown returns a new Enum associated with the given name and type T
+// that represents unknown values. It must be the first enum registered for
+// T (so its ID is 0, which also rules out enums that were unregistered) and
+// it is automatically set as the fallback for decoding unknown names (see
+// SetUnknownFallback).
+func WithUnknown[T constraints.Integer](name string) Enum[T] {
+	if name == "" {
+		panic("enum name cannot be empty")
+	}
+
+	return Enum[T]{internalEnumWrapper[T]{getOrCreateSetForType[T]().AddUnknown(name)}}
+}
+
+// NewMany returns new Enums associated with the given names and type T, in
+// the given order, so IDs are contiguous. Nothing is registered if any of the
+// names is invalid, in which case this panics with a message that includes
+// the index of the offending name.
+func NewMany[T constraints.Integer](names ...string) []Enum[T] {
+	enums, err := TryNewMany[T](names...)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return enums
+}
+
+// TryNewMany is like NewMany but returns a non-nil error instead of
+// panicking.
+func TryNewMany[T constraints.Integer](names ...string) ([]Enum[T], error) {
+	added, err := getOrCreateSetForType[T]().TryAddMany(names)
+	if err != nil {
+		return nil, err
+	}
 
-	enums := make([]Enum[T], 0, len(nameEnumMap))
-	for _, e := range nameEnumMap {
+	enums := make([]Enum[T], 0, len(added))
+	for _, e := range added {
 		enums = append(enums, Enum[T]{internalEnumWrapper[T]{e}})
 	}
 
+	return enums, nil
+}
+
+// NewWithDescription returns a new Enum associated with the given name,
+// description and type T. The description is meant to be human-readable
+// (for tooltips, documentation, etc) and is not used for lookups.
+func NewWithDescription[T constraints.Integer](name, description string) Enum[T] {
+	return New(name, WithDescription[T](description))
+}
+
+// NewWithLabel returns a new Enum associated with the given name, display
+// label and type T. The label is only meant for presentation. Marshalling and
+// lookups always use the canonical name.
+func NewWithLabel[T constraints.Integer](name, label string) Enum[T] {
+	return New(name, WithLabel[T](label))
+}
+
+// Freeze seals the enum set associated with type T. Any subsequent attempt to
+// create a new enum of type T or to add an alias panics and Unregister
+// returns an error.
+func Freeze[T constraints.Integer]() {
+	getOrCreateSetForType[T]().Freeze()
+}
+
+// IsFrozen returns true if the enum set associated with type T was frozen by
+// Freeze or false otherwise.
+func IsFrozen[T constraints.Integer]() bool {
+	s := getSetForType[T]()
+
+	return s != nil && s.IsFrozen()
+}
+
+// RegisteredTypes returns the sorted names of all types that have an enum set
+// associated with them.
+func RegisteredTypes() []string {
+	setByTypeMu.RLock()
+	defer setByTypeMu.RUnlock()
+
+	typeNames := make([]string, 0, len(setByType))
+	for _, s := range setByType {
+		typeNames = append(typeNames, s.TypeName())
+	}
+
+	sort.Strings(typeNames)
+
+	return typeNames
+}
+
+// NamesForType returns the names of all enums associated with the type with
+// the given name (as returned by RegisteredTypes), in ID order. This allows
+// introspecting enum sets without knowing their types at compile time. If no
+// such type exists or more than one type has the given name (which is
+// possible for types declared inside function bodies), a non-nil error is
+// returned.
+func NamesForType(typeName string) ([]string, error) {
+	setByTypeMu.RLock()
+	defer setByTypeMu.RUnlock()
+
+	var found untypedSet
+	for _, s := range setByType {
+		if s.TypeName() != typeName {
+			continue
+		}
+
+		if found != nil {
+			return nil, fmt.Errorf("more than one enum type named %s", typeName)
+		}
+
+		found = s
+	}
+
+	if found == nil {
+		return nil, &LookupError{TypeName: typeName, Err: ErrTypeNotRegistered}
+	}
+
+	return found.Names(), nil
+}
+
+// Names returns the names of all enums associated with the given type T, in
+// ID order. If no enums are associated with T, this returns an empty slice.
+func Names[T constraints.Integer]() []string {
+	s := getSetForType[T]()
+	if s == nil {
+		return []string{}
+	}
+
+	return s.Names()
+}
+
+// Values is an alias of Names, matching the convention used by stringer and
+// GraphQL generators.
+func Values[T constraints.Integer]() []string {
+	return Names[T]()
+}
+
+// onceByType holds the guards used by Once.
+var onceByType sync.Map // map[reflect.Type]*sync.Once
+
+// Once calls fn only the first time it is called for type T, even if called
+// concurrently, and all calls return only after fn returns. This supports
+// registering enums lazily (outside of package initialization) by calling
+// Once with the same registration function before any use:
+//
+//	func roles() { enum.Once[Role](registerRoles) }
+func Once[T constraints.Integer](fn func()) {
+	once, _ := onceByType.LoadOrStore(getType[T](), &sync.Once{})
+	once.(*sync.Once).Do(fn)
+}
+
+// Reset removes the enum set associated with type T, allowing enums of that
+// type to be registered again from scratch. Existing Enum instances of type T
+// are not affected but will not be found by lookups anymore. This also resets
+// the guard used by Once for T.
+//
+// This is intended to be used only in tests.
+func Reset[T constraints.Integer]() {
+	setByTypeMu.Lock()
+	defer setByTypeMu.Unlock()
+
+	delete(setByType, getType[T]())
+	onceByType.Delete(getType[T]())
+}
+
+// Unregister removes the enum with the given name (but not alias) from the
+// enum set associated with type T, along with its aliases. Its name and ID
+// can then be used by new enums. Existing Enum instances are not affected
+// but will not be found by lookups anymore. This returns an error if the set
+// was frozen by Freeze.
+func Unregister[T constraints.Integer](name string) error {
+	typeName := getTypeName[T]()
+
+	s := getSetForType[T]()
+	if s == nil {
+		return &LookupError{TypeName: typeName, Name: name, Err: ErrTypeNotRegistered}
+	}
+
+	e, err := s.Remove(name)
+	if err != nil {
+		return fmt.Errorf("cannot unregister %s from type %s: %w", name, typeName, err)
+	}
+
+	if e == nil {
+		return &LookupError{TypeName: typeName, Name: name, Err: ErrUnknownName}
+	}
+
+	return nil
+}
+
+// EnumsByType returns all enums associated with the given type T, in ID
+// order. The returned slice is a copy and can be freely modified. If there
+// are no enums associated with T, the slice is empty.
+func EnumsByType[T constraints.Integer]() []Enum[T] {
+	s := getSetForType[T]()
+	if s == nil {
+		return []Enum[T]{}
+	}
+
+	enums := s.Enums()
+
+	return append(make([]Enum[T], 0, len(enums)), enums...)
+}
+
+// Count returns the number of enums associated with the given type T.
+func Count[T constraints.Integer]() int {
+	s := getSetForType[T]()
+	if s == nil {
+		return 0
+	}
+
+	return s.Len()
+}
+
+// Capacity returns how many enums with auto-generated IDs type T can hold in
+// total. Auto-generated IDs start at 0 and end at the maximum value of T, so
+// this is, for example, 256 for uint8 and 128 for int8. The result saturates
+// at math.MaxUint64.
+func Capacity[T constraints.Integer]() uint64 {
+	maxID := maxIDForType[T]()
+	if maxID == math.MaxUint64 {
+		return maxID
+	}
+
+	return maxID + 1
+}
+
+// Remaining returns how many more enums with auto-generated IDs can be
+// associated with type T before New panics because IDs are exhausted. The
+// result saturates at math.MaxUint64.
+func Remaining[T constraints.Integer]() uint64 {
+	s := getSetForType[T]()
+	if s == nil {
+		return Capacity[T]()
+	}
+
+	return s.Remaining()
+}
+
+// Exhausted returns true if all auto-generated IDs of type T were used, in
+// which case New panics. Explicit IDs (see NewWithID) can still be used.
+func Exhausted[T constraints.Integer]() bool {
+	s := getSetForType[T]()
+
+	return s != nil && s.Exhausted()
+}
+
+// ForEach calls fn for each enum associated with the given type T, in ID
+// order, until fn returns false or there are no more enums.
+func ForEach[T constraints.Integer](fn func(Enum[T]) bool) {
+	s := getSetForType[T]()
+	if s == nil {
+		return
+	}
+
+	for _, e := range s.Ordered() {
+		if !fn(Enum[T]{internalEnumWrapper[T]{e}}) {
+			return
+		}
+	}
+}
+
+// Filter returns, in ID order, all enums associated with the given type T for
+// which pred returns true. If there are no such enums (including when no
+// enums are associated with T), an empty slice is returned.
+func Filter[T constraints.Integer](pred func(Enum[T]) bool) []Enum[T] {
+	enums := make([]Enum[T], 0)
+	ForEach(func(e Enum[T]) bool {
+		if pred(e) {
+			enums = append(enums, e)
+		}
+
+		return true
+	})
+
 	return enums
 }
 
+// VisibleEnumsByType returns all enums associated with the given type T that
+// were not deprecated.
+func VisibleEnumsByType[T constraints.Integer]() []Enum[T] {
+	enums := EnumsByType[T]()
+
+	visible := enums[:0]
+	for _, e := range enums {
+		if !e.IsDeprecated() {
+			visible = append(visible, e)
+		}
+	}
+
+	return visible
+}
+
+// ValidateContiguous returns a non-nil error if the IDs of the enums of type
+// T do not form a contiguous range starting at 0 (i.e. if there are gaps or
+// duplicates) or if the type has no enums associated with it (even if it was
+// configured with SetEncoding, Freeze, etc), in which case the error wraps
+// ErrTypeNotRegistered. This is useful for asserting that IDs can safely be
+// used as slice indexes.
+func ValidateContiguous[T constraints.Integer]() error {
+	enums := EnumsByType[T]()
+	if len(enums) == 0 {
+		return &LookupError{TypeName: getTypeName[T](), Err: ErrTypeNotRegistered}
+	}
+
+	for i, e := range enums {
+		if i > 0 && e.ID() == enums[i-1].ID() {
+			return fmt.Errorf("enums %s and %s of type %s have the same ID %d", enums[i-1].Name(), e.Name(), getTypeName[T](), e.ID())
+		}
+
+		if e.ID() != T(i) {
+			return fmt.Errorf("enum IDs of type %s have a gap: expected ID %d, got %d for %s", getTypeName[T](), i, e.ID(), e.Name())
+		}
+	}
+
+	return nil
+}
+
 // EnumByTypeAndName returns the enum associated with the given type and name.
-// If there is no such enum, a non-nil error is returned.
+// If there is no such enum, a non-nil *LookupError wrapping either
+// ErrTypeNotRegistered or ErrUnknownName is returned.
 func EnumByTypeAndName[T constraints.Integer](name string) (Enum[T], error) {
 	e, err := getInternalEnumForName[T](name)
 	if err != nil {
@@ -86,26 +488,507 @@ func EnumByTypeAndName[T constraints.Integer](name string) (Enum[T], error) {
 	return Enum[T]{internalEnumWrapper[T]{e}}, nil
 }
 
+// MustGet is like EnumByTypeAndName but panics if there is no such enum. It is
+// intended for tests and static initialization.
+func MustGet[T constraints.Integer](name string) Enum[T] {
+	e, err := EnumByTypeAndName[T](name)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return e
+}
+
+// EnumByTypeAndID returns the enum associated with the given type and ID. If
+// there is no such enum, a non-nil *LookupError wrapping either
+// ErrTypeNotRegistered or ErrUnknownID is returned.
+func EnumByTypeAndID[T constraints.Integer](id T) (Enum[T], error) {
+	e, err := getInternalEnumForID(id)
+	if err != nil {
+		return Enum[T]{}, err
+	}
+
+	return Enum[T]{internalEnumWrapper[T]{e}}, nil
+}
+
+// MustEnumByTypeAndID is like EnumByTypeAndID but panics if there is no such
+// enum. It is intended for trusted paths where the ID is known to be valid.
+func MustEnumByTypeAndID[T constraints.Integer](id T) Enum[T] {
+	e, err := EnumByTypeAndID(id)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return e
+}
+
+// Ceiling returns the enum of type T with the smallest ID greater than or
+// equal to id. If there is no such enum, this returns false.
+func Ceiling[T constraints.Integer](id T) (Enum[T], bool) {
+	s := getSetForType[T]()
+	if s == nil {
+		return Enum[T]{}, false
+	}
+
+	e := s.Ceiling(id)
+
+	return Enum[T]{internalEnumWrapper[T]{e}}, e != nil
+}
+
+// Higher returns the enum of type T with the smallest ID strictly greater
+// than id. For iterating over sparse IDs (set with NewWithID) in order,
+// Higher(e.ID()) returns the enum immediately following e. If there is no
+// such enum, this returns false.
+func Higher[T constraints.Integer](id T) (Enum[T], bool) {
+	s := getSetForType[T]()
+	if s == nil {
+		return Enum[T]{}, false
+	}
+
+	e := s.Higher(id)
+
+	return Enum[T]{internalEnumWrapper[T]{e}}, e != nil
+}
+
+// Floor returns the enum of type T with the largest ID less than or equal to
+// id. If there is no such enum, this returns false.
+func Floor[T constraints.Integer](id T) (Enum[T], bool) {
+	s := getSetForType[T]()
+	if s == nil {
+		return Enum[T]{}, false
+	}
+
+	e := s.Floor(id)
+
+	return Enum[T]{internalEnumWrapper[T]{e}}, e != nil
+}
+
+// IsValidID returns true if an enum with the given ID is associated with the
+// given type T or false otherwise.
+func IsValidID[T constraints.Integer](id T) bool {
+	s := getSetForType[T]()
+	if s == nil {
+		return false
+	}
+
+	return s.HasID(id)
+}
+
+// IsValidName returns true if an enum with the given name (or alias) is
+// associated with the given type T or false otherwise.
+func IsValidName[T constraints.Integer](name string) bool {
+	s := getSetForType[T]()
+
+	return s != nil && s.Get(name) != nil
+}
+
+// Resolve returns the enum associated with the given type and v, which can be
+// a name, an ID (any integer type) or an ID as a string. For strings, the
+// name lookup is tried first and, if it fails and v parses as an integer
+// within the range of T, the ID lookup is tried next. If the lookup fails or
+// v has an unsupported type, a non-nil error is returned. For strings, it
+// wraps the *LookupError of the name lookup.
+func Resolve[T constraints.Integer](v any) (Enum[T], error) {
+	switch v := v.(type) {
+	case string:
+		return resolveString[T](v)
+	case []byte:
+		return resolveString[T](string(v))
+	case T:
+		return EnumByTypeAndID(v)
+	}
+
+	value := reflect.ValueOf(v)
+
+	var id T
+	var ok bool
+
+	switch value.Kind() {
+	case reflect.String:
+		return resolveString[T](value.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := value.Int()
+		id = T(i)
+		ok = int64(id) == i && (i < 0) == (id < 0)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := value.Uint()
+		id = T(u)
+		ok = uint64(id) == u && id >= 0
+	default:
+		return Enum[T]{}, fmt.Errorf("unsupported type %T for resolving enums of type %s", v, getTypeName[T]())
+	}
+
+	if !ok {
+		return Enum[T]{}, fmt.Errorf("ID %v out of range for type %s", v, getTypeName[T]())
+	}
+
+	return EnumByTypeAndID(id)
+}
+
+// resolveString implements Resolve for strings.
+func resolveString[T constraints.Integer](s string) (Enum[T], error) {
+	e, nameErr := getInternalEnumForName[T](s)
+	if nameErr == nil {
+		return Enum[T]{internalEnumWrapper[T]{e}}, nil
+	}
+
+	id, parseErr := parseID[T](s)
+	if parseErr != nil {
+		return Enum[T]{}, fmt.Errorf("%s could not be resolved as a name: %w", s, nameErr)
+	}
+
+	e, idErr := getInternalEnumForID[T](id)
+	if idErr == nil {
+		return Enum[T]{internalEnumWrapper[T]{e}}, nil
+	}
+
+	return Enum[T]{}, fmt.Errorf("%s could not be resolved as a name (%w) or as an ID (%v)", s, nameErr, idErr)
+}
+
+// Convert returns the enum of type To with the same name as e, for mapping
+// between enum types declared in different packages that mirror each other.
+// IDs do not need to match. If e is invalid or there is no such enum, a
+// non-nil error is returned.
+func Convert[From, To constraints.Integer](e Enum[From]) (Enum[To], error) {
+	if !e.Valid() {
+		return Enum[To]{}, fmt.Errorf("cannot convert invalid enum of type %s to %s", getTypeName[From](), getTypeName[To]())
+	}
+
+	return EnumByTypeAndName[To](e.internalEnum.name)
+}
+
+// ResolveAll is like Resolve but for a slice of names (or IDs as strings). The
+// returned slice always has the same length as names, with the zero Enum at
+// the index of every name that could not be resolved, so callers can make use
+// of partial results. If any name could not be resolved, the returned error
+// lists all such names with their indexes.
+func ResolveAll[T constraints.Integer](names []string) ([]Enum[T], error) {
+	enums := make([]Enum[T], len(names))
+
+	var errs []error
+	for i, name := range names {
+		e, err := resolveString[T](name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("index %d: %w", i, err))
+			continue
+		}
+
+		enums[i] = e
+	}
+
+	if len(errs) > 0 {
+		return enums, &multiError{errs}
+	}
+
+	return enums, nil
+}
+
+// parseID parses s as a base 10 integer that fits in T.
+func parseID[T constraints.Integer](s string) (T, error) {
+	var zero T
+
+	bitSize := reflect.TypeOf(zero).Bits()
+
+	if isSigned[T]() {
+		id, err := strconv.ParseInt(s, 10, bitSize)
+		if err != nil {
+			return zero, err
+		}
+
+		return T(id), nil
+	}
+
+	id, err := strconv.ParseUint(s, 10, bitSize)
+	if err != nil {
+		return zero, err
+	}
+
+	return T(id), nil
+}
+
 // internalEnumWrapper is the type that implements all Enum methods.
 type internalEnumWrapper[T constraints.Integer] struct {
 	*internalEnum[T]
 }
 
-// Name returns the name associated with this Enum instance.
+// Name returns the name associated with this Enum instance. For invalid Enum
+// instances, this panics unless disabled with SetPanicOnInvalid, in which
+// case it returns an empty string.
 func (e internalEnumWrapper[T]) Name() string {
 	if !e.Valid() {
-		panic("enum not initialized")
+		panicOnInvalid[T]()
+		return ""
 	}
 
 	return e.internalEnum.name
 }
 
-// ID returns the numeric ID associated with this Enum instance.
+// ID returns the numeric ID associated with this Enum instance. For invalid
+// Enum instances, this panics unless disabled with SetPanicOnInvalid, in
+// which case it returns the zero value of T.
 func (e internalEnumWrapper[T]) ID() T {
+	if !e.Valid() {
+		panicOnInvalid[T]()
+		return 0
+	}
+
+	return e.internalEnum.id
+}
+
+// SetPanicOnInvalid controls whether Name and ID panic when called on invalid
+// enums of type T (the default) or return zero values instead. String never
+// panics. Disabling this trades strictness for resilience in code that
+// can not guarantee enums are initialized.
+func SetPanicOnInvalid[T constraints.Integer](panicOnInvalid bool) {
+	getOrCreateSetForType[T]().lenient = !panicOnInvalid
+}
+
+// panicOnInvalid panics unless disabled for type T with SetPanicOnInvalid.
+func panicOnInvalid[T constraints.Integer]() {
+	if s := getSetForType[T](); s == nil || !s.lenient {
+		panic("enum not initialized")
+	}
+}
+
+// IsID returns true if this Enum instance is valid and has the given ID or
+// false otherwise.
+func (e internalEnumWrapper[T]) IsID(id T) bool {
+	return e.Valid() && e.internalEnum.id == id
+}
+
+// Code returns a stable identifier for this Enum instance composed of the
+// type name and the enum name (e.g. "github.com/org/accounts.Role/Admin").
+// Contrary to the ID, it does not change if declaration order changes, so it
+// is the recommended key for persisting enums externally (cache keys,
+// sharding, etc).
+func (e internalEnumWrapper[T]) Code() string {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	return getTypeName[T]() + "/" + e.internalEnum.name
+}
+
+// BelongsTo returns the fully qualified name of the type T of the enum set
+// this Enum instance belongs to (e.g. "github.com/org/accounts.Role"), as
+// listed by RegisteredTypes. Derived types (type RoleEnum Enum[Role]) belong
+// to the same set as Enum[Role]. Generic code handling enums as interface
+// values can compare the result with the BelongsTo of a known enum to verify
+// where a value came from. This works for invalid enums too.
+func (e internalEnumWrapper[T]) BelongsTo() string {
+	return getTypeName[T]()
+}
+
+// Ordinal returns the 0-based index of this Enum instance among all enums of
+// the same type sorted by ID. For auto-generated IDs this is the same as the
+// ID but, with explicit IDs, it provides a dense index that can be used for
+// array lookups. This returns -1 if the enum set was reset after this Enum
+// instance was created.
+func (e internalEnumWrapper[T]) Ordinal() int {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	s := getSetForType[T]()
+	if s == nil {
+		return -1
+	}
+
+	return s.Ordinal(e.internalEnum)
+}
+
+// IsFirst returns true if this Enum instance is the one with the lowest ID
+// among all enums of the same type or false otherwise, including for invalid
+// enums.
+func (e internalEnumWrapper[T]) IsFirst() bool {
+	enums := e.orderedEnums()
+
+	return len(enums) > 0 && enums[0] == e.internalEnum
+}
+
+// IsLast returns true if this Enum instance is the one with the highest ID
+// among all enums of the same type or false otherwise, including for invalid
+// enums.
+func (e internalEnumWrapper[T]) IsLast() bool {
+	enums := e.orderedEnums()
+
+	return len(enums) > 0 && enums[len(enums)-1] == e.internalEnum
+}
+
+// orderedEnums returns all enums of type T in ID order, or nil if this Enum
+// instance is invalid or there are no enums associated with T.
+func (e internalEnumWrapper[T]) orderedEnums() []*internalEnum[T] {
+	if !e.Valid() {
+		return nil
+	}
+
+	s := getSetForType[T]()
+	if s == nil {
+		return nil
+	}
+
+	return s.Ordered()
+}
+
+// Description returns the description associated with this Enum instance. It
+// is empty for Enums created without one.
+func (e internalEnumWrapper[T]) Description() string {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	return e.internalEnum.description
+}
+
+// Label returns the display label associated with this Enum instance. If no
+// label was set, this returns the Enum name.
+func (e internalEnumWrapper[T]) Label() string {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	if e.internalEnum.label == "" {
+		return e.internalEnum.name
+	}
+
+	return e.internalEnum.label
+}
+
+// AddAlias registers an additional name that resolves to this Enum instance
+// in lookups (including unmarshalling). Name still returns the canonical
+// name. This panics if the alias is empty or already in use by any enum of
+// the same type, or if the enum set of the type was frozen by Freeze.
+func (e internalEnumWrapper[T]) AddAlias(alias string) {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	if alias == "" {
+		panic("enum alias cannot be empty")
+	}
+
+	s := getSetForType[T]()
+	if s == nil || s.Get(e.internalEnum.name) != e.internalEnum {
+		panic("enum not registered")
+	}
+
+	s.AddAlias(e.internalEnum, alias)
+}
+
+// enumStateMu guards the mutable state of all enums (metadata and
+// deprecation). Enums are shared between snapshots, so a per-set lock would
+// not be enough.
+var enumStateMu sync.RWMutex
+
+// Deprecate marks this Enum instance as deprecated. Deprecated enums keep
+// working as usual (so existing serialized data can still be read) but are
+// skipped by VisibleEnumsByType.
+func (e internalEnumWrapper[T]) Deprecate() {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	enumStateMu.Lock()
+	defer enumStateMu.Unlock()
+
+	e.internalEnum.deprecated = true
+}
+
+// IsDeprecated returns true if this Enum instance was deprecated or false
+// otherwise.
+func (e internalEnumWrapper[T]) IsDeprecated() bool {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	enumStateMu.RLock()
+	defer enumStateMu.RUnlock()
+
+	return e.internalEnum.deprecated
+}
+
+// SetMeta associates the given metadata value with the given key for this
+// Enum instance, replacing any existing value for the same key. It is safe to
+// call concurrently with Meta.
+func (e internalEnumWrapper[T]) SetMeta(key string, value any) {
 	if !e.Valid() {
 		panic("enum not initialized")
 	}
 
+	enumStateMu.Lock()
+	defer enumStateMu.Unlock()
+
+	if e.internalEnum.meta == nil {
+		// Lazily allocated so enums without metadata cost nothing.
+		e.internalEnum.meta = make(map[string]any)
+	}
+
+	e.internalEnum.meta[key] = value
+}
+
+// Meta returns the metadata value associated with the given key for this
+// Enum instance. The boolean result is false if no such value exists.
+func (e internalEnumWrapper[T]) Meta(key string) (any, bool) {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	enumStateMu.RLock()
+	defer enumStateMu.RUnlock()
+
+	value, ok := e.internalEnum.meta[key]
+
+	return value, ok
+}
+
+// Clone returns a copy of this Enum instance. The copy shares the same
+// underlying data, so it compares equal (==) to the original and matches the
+// same switch cases. Cloning an invalid Enum returns an invalid Enum.
+func (e internalEnumWrapper[T]) Clone() Enum[T] {
+	return Enum[T]{internalEnumWrapper[T]{e.internalEnum}}
+}
+
+// Equal returns true if this Enum instance and other are the same enum. All
+// invalid Enum instances are equal to each other and never equal to valid
+// ones. This is the same as == but is documented to be a total equality and
+// can be used where a method is needed (generic containers, go-cmp, etc).
+func (e internalEnumWrapper[T]) Equal(other Enum[T]) bool {
+	return e.internalEnum == other.internalEnum
+}
+
+// In returns true if this Enum instance is equal to any of the given options
+// or false otherwise. Invalid Enum instances are never in any options.
+func (e internalEnumWrapper[T]) In(options ...Enum[T]) bool {
+	if !e.Valid() {
+		return false
+	}
+
+	for _, option := range options {
+		if option.internalEnum == e.internalEnum {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NameOr returns the name associated with this Enum instance or the given
+// fallback if the Enum is not valid.
+func (e internalEnumWrapper[T]) NameOr(fallback string) string {
+	if !e.Valid() {
+		return fallback
+	}
+
+	return e.internalEnum.name
+}
+
+// IDOr returns the numeric ID associated with this Enum instance or the given
+// fallback if the Enum is not valid.
+func (e internalEnumWrapper[T]) IDOr(fallback T) T {
+	if !e.Valid() {
+		return fallback
+	}
+
 	return e.internalEnum.id
 }
 
@@ -116,43 +999,219 @@ func (e *internalEnumWrapper[T]) Valid() bool {
 	return e.internalEnum != nil
 }
 
-// MarshalJSON implements the json.Marshaler interface.
+// Validate returns a non-nil error if the Enum is not valid or nil otherwise.
+// This allows validation frameworks that call Validate on struct fields to
+// automatically reject uninitialized enums.
+func (e internalEnumWrapper[T]) Validate() error {
+	if !e.Valid() {
+		return fmt.Errorf("enum of type %s not initialized", getTypeName[T]())
+	}
+
+	return nil
+}
+
+// IsZero returns true if the Enum is the zero value (and, thus, invalid) or
+// false otherwise. This is the inverse of Valid and exists so serialization
+// libraries that look for an IsZero method can skip uninitialized enums.
+func (e internalEnumWrapper[T]) IsZero() bool {
+	return !e.Valid()
+}
+
+// MarshalJSON implements the json.Marshaler interface. Enums are encoded
+// according to the EncodingMode set for their type with SetEncoding (names by
+// default). Invalid enums are an error unless SetMarshalInvalidAsNull was
+// enabled for their type.
 func (e internalEnumWrapper[T]) MarshalJSON() ([]byte, error) {
 	if !e.Valid() {
+		if s := getSetForType[T](); s != nil && s.invalidAsNull {
+			return []byte("null"), nil
+		}
+
 		return nil, fmt.Errorf("enum not initialized")
 	}
 
-	return json.Marshal(e.Name())
+	switch getEncoding[T]() {
+	case EncodeID:
+		return json.Marshal(e.internalEnum.id)
+	case EncodeObject:
+		return marshalJSONObject(e.internalEnum)
+	default:
+		return json.Marshal(toJSONName[T](e.internalEnum.name))
+	}
+}
+
+// SetNameNormalizer sets a function applied to all names and aliases of enums
+// of type T when registering and when looking them up (decoding included), so
+// they are stored in and resolved by a canonical form. For example, with
+// strings.TrimSpace, New[T](" Admin ") registers "Admin" and " Admin" resolves
+// to it. This panics if enums were already associated with T.
+func SetNameNormalizer[T constraints.Integer](fn func(string) string) {
+	s := getOrCreateSetForType[T]()
+	if s.Len() > 0 {
+		panic("name normalizer must be set before registering enums")
+	}
+
+	s.normalizer = fn
+}
+
+// SetNameValidator sets a function that validates the (normalized) names of
+// new enums of type T. Registering an enum whose name fails validation panics
+// (or returns an error for TryNewMany) with the validation error. Enums that
+// were already registered are not validated. Passing nil disables validation.
+func SetNameValidator[T constraints.Integer](fn func(string) error) {
+	getOrCreateSetForType[T]().SetValidator(fn)
+}
+
+// GoIdentifierValidator is a name validator for use with SetNameValidator
+// that only accepts valid Go identifiers (which are not keywords), as required
+// for generating code with enum names.
+func GoIdentifierValidator(name string) error {
+	if !token.IsIdentifier(name) {
+		return fmt.Errorf("%q is not a valid Go identifier", name)
+	}
+
+	return nil
+}
+
+// SetMarshalInvalidAsNull controls whether MarshalJSON encodes invalid enums
+// of type T as null instead of returning an error. Combined with omitempty or
+// pointer fields, this allows optional enum fields. This is disabled by
+// default.
+func SetMarshalInvalidAsNull[T constraints.Integer](asNull bool) {
+	getOrCreateSetForType[T]().invalidAsNull = asNull
 }
 
 func getInternalEnumForName[T constraints.Integer](name string) (*internalEnum[T], error) {
 	typeName := getTypeName[T]()
 
-	anySet, ok := setByTypeName[typeName]
-	if !ok {
-		return nil, fmt.Errorf("no enum set associated with type %s", typeName)
+	s := getSetForType[T]()
+	if s == nil {
+		return nil, &LookupError{TypeName: typeName, Name: name, Err: ErrTypeNotRegistered}
 	}
 
-	s := anySet.(*internalSet[T])
-
 	var e *internalEnum[T]
 	if e = s.Get(name); e == nil {
-		return nil, fmt.Errorf("name %s could not be found in enum set for type %s", name, typeName)
+		return nil, &LookupError{TypeName: typeName, Name: name, Err: ErrUnknownName}
+	}
+
+	return e, nil
+}
+
+// getInternalEnumForDecoding is like getInternalEnumForName but, if the name is
+// unknown and a fallback was set for type T with SetUnknownFallback, returns
+// the fallback instead of an error. It is used by all decoding methods.
+func getInternalEnumForDecoding[T constraints.Integer](name string) (*internalEnum[T], error) {
+	e, err := getInternalEnumForName[T](name)
+	if err != nil && errors.Is(err, ErrUnknownName) {
+		if fallback := getSetForType[T]().Fallback(); fallback != nil {
+			return fallback, nil
+		}
+	}
+
+	return e, err
+}
+
+// getInternalEnumForDecodingOrID is like getInternalEnumForDecoding but, if
+// tryID is true and there is no enum with the given name, first tries to
+// resolve the name as an ID. Names always take precedence over IDs.
+func getInternalEnumForDecodingOrID[T constraints.Integer](name string, tryID bool) (*internalEnum[T], error) {
+	if tryID && !IsValidName[T](name) {
+		if id, err := parseID[T](name); err == nil {
+			if e, err := getInternalEnumForID(id); err == nil {
+				return e, nil
+			}
+		}
+	}
+
+	return getInternalEnumForDecoding[T](name)
+}
+
+// SetNumericText controls whether UnmarshalText for enums of type T also
+// accepts the decimal representation of IDs. Names that happen to be numeric
+// take precedence over IDs. This is disabled by default.
+func SetNumericText[T constraints.Integer](numeric bool) {
+	getOrCreateSetForType[T]().numericText = numeric
+}
+
+// SetUnknownFallback sets the enum to be used by UnmarshalJSON, UnmarshalText
+// and Scan when decoding unknown names for type T. Without a fallback (the
+// default), decoding unknown names returns an error.
+func SetUnknownFallback[T constraints.Integer](fallback Enum[T]) {
+	if !fallback.Valid() {
+		panic("enum not initialized")
+	}
+
+	getOrCreateSetForType[T]().SetFallback(fallback.internalEnum)
+}
+
+func getInternalEnumForID[T constraints.Integer](id T) (*internalEnum[T], error) {
+	typeName := getTypeName[T]()
+
+	s := getSetForType[T]()
+	if s == nil {
+		return nil, &LookupError{TypeName: typeName, ID: id, Err: ErrTypeNotRegistered}
+	}
+
+	e, err := s.GetByID(id)
+	if err != nil {
+		return nil, &LookupError{TypeName: typeName, ID: id, Err: ErrUnknownID}
 	}
 
 	return e, nil
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface.
+// UnmarshalJSON implements the json.Unmarshaler interface. The source can be
+// a string with the enum name, a number with the enum ID or an object with
+// both (see EncodeObject). Surrounding whitespace in names is only ignored if
+// enabled with SetTrimSpace. As is conventional for encoding/json, null is a
+// no-op and leaves the enum unchanged.
 func (e *internalEnumWrapper[T]) UnmarshalJSON(data []byte) error {
 	var name string
 	var err error
 
+	trimmed := bytes.TrimSpace(data)
+	if string(trimmed) == "null" {
+		return nil
+	}
+
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		e.internalEnum, err = unmarshalJSONObject[T](trimmed)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
 	if err = json.Unmarshal(data, &name); err != nil {
-		return fmt.Errorf("source should be a string, got %s", data)
+		var number json.Number
+		if err = json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("source should be a string or a number, got %s", data)
+		}
+
+		return e.unmarshalID(number.String())
 	}
 
-	e.internalEnum, err = getInternalEnumForName[T](name)
+	if s := getSetForType[T](); s != nil && s.trimSpace {
+		name = strings.TrimSpace(name)
+	}
+
+	e.internalEnum, err = getInternalEnumForDecoding[T](fromJSONName[T](name))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// unmarshalID sets e to the enum with the ID represented by s.
+func (e *internalEnumWrapper[T]) unmarshalID(s string) error {
+	id, err := parseID[T](s)
+	if err != nil {
+		return fmt.Errorf("invalid enum ID %s: %w", s, err)
+	}
+
+	e.internalEnum, err = getInternalEnumForID(id)
 	if err != nil {
 		return err
 	}
@@ -160,40 +1219,107 @@ func (e *internalEnumWrapper[T]) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// MarshalText implements the encoding.TextMarshaler interface.
+// SetTrimSpace controls whether UnmarshalJSON ignores whitespace surrounding
+// enum names of type T. This is disabled by default.
+func SetTrimSpace[T constraints.Integer](trim bool) {
+	getOrCreateSetForType[T]().trimSpace = trim
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. Together with
+// UnmarshalText, this is what most CSV libraries (like gocarina/gocsv) and
+// configuration libraries use to encode and decode fields. Enums are encoded
+// as their decimal ID if EncodeID was set for their type with SetEncoding and
+// as their name otherwise.
 func (e internalEnumWrapper[T]) MarshalText() ([]byte, error) {
 	if !e.Valid() {
 		return nil, fmt.Errorf("enum not initialized")
 	}
 
+	if getEncoding[T]() == EncodeID {
+		return []byte(fmt.Sprint(e.internalEnum.id)), nil
+	}
+
 	return []byte(e.Name()), nil
 }
 
-// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// UnmarshalText implements the encoding.TextUnmarshaler interface. A single
+// trailing line terminator ("\n" or "\r\n") is ignored so names read from
+// line-oriented sources resolve correctly. Use SetStrictText to disable this.
+// If enabled with SetNumericText, numeric text is also resolved as an ID when
+// there is no enum with that name. If EncodeID was set for the type with
+// SetEncoding, numeric text is always resolved as an ID, even if there is an
+// enum with that name (and other names are still accepted). Errors include
+// the given text and wrap the lookup error, so they are diagnosable when
+// surfaced by configuration libraries.
 func (e *internalEnumWrapper[T]) UnmarshalText(text []byte) error {
 	name := string(text)
 
+	s := getSetForType[T]()
+	if s == nil || !s.strictText {
+		name = trimLineTerminator(name)
+	}
+
 	var err error
-	e.internalEnum, err = getInternalEnumForName[T](name)
+
+	if s != nil && s.encoding == EncodeID {
+		if id, parseErr := parseID[T](name); parseErr == nil {
+			e.internalEnum, err = getInternalEnumForID(id)
+			if err != nil {
+				return fmt.Errorf("invalid enum text %q: %w", text, err)
+			}
+
+			return nil
+		}
+	}
+
+	e.internalEnum, err = getInternalEnumForDecodingOrID[T](name, s != nil && s.numericText)
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid enum text %q: %w", text, err)
 	}
 
 	return nil
 }
 
-// Value implements the driver.Valuer interface.
+// SetStrictText controls whether UnmarshalText for enums of type T requires
+// an exact name match. By default it is lenient and ignores a single trailing
+// line terminator.
+func SetStrictText[T constraints.Integer](strict bool) {
+	getOrCreateSetForType[T]().strictText = strict
+}
+
+// trimLineTerminator removes a single trailing "\r\n" or "\n" from s.
+func trimLineTerminator(s string) string {
+	if strings.HasSuffix(s, "\r\n") {
+		return s[:len(s)-2]
+	}
+
+	return strings.TrimSuffix(s, "\n")
+}
+
+// Value implements the driver.Valuer interface. It returns the name, unless a
+// label function was set with SetSQLLabel for the type.
 func (e internalEnumWrapper[T]) Value() (driver.Value, error) {
 	if !e.Valid() {
 		return nil, fmt.Errorf("enum not initialized")
 	}
 
+	if s := getSetForType[T](); s != nil && s.sqlLabel != nil {
+		return s.sqlLabel(Enum[T]{e}), nil
+	}
+
 	return e.Name(), nil
 }
 
-// Scan implements the sql.Scanner interface.
+// Scan implements the sql.Scanner interface. Values must be names (or labels,
+// if SetSQLLabel was used for the type), unless SetScanNumeric was enabled for
+// the type. NULL leaves the enum unchanged unless SetScanNullStrict was
+// enabled for its type, in which case it is an error.
 func (e *internalEnumWrapper[T]) Scan(value any) error {
 	if value == nil {
+		if s := getSetForType[T](); s != nil && s.scanNullStrict {
+			return fmt.Errorf("cannot scan NULL into enum of type %s", getTypeName[T]())
+		}
+
 		return nil
 	}
 
@@ -207,6 +1333,67 @@ func (e *internalEnumWrapper[T]) Scan(value any) error {
 		name = string(bytes)
 	}
 
+	s := getSetForType[T]()
+	if s != nil && s.sqlLabel != nil {
+		for _, candidate := range s.Enums() {
+			if s.sqlLabel(candidate) == name {
+				e.internalEnum = candidate.internalEnum
+				return nil
+			}
+		}
+	}
+
+	if s != nil && s.scanFold {
+		if found := s.GetFold(name); found != nil {
+			e.internalEnum = found
+			return nil
+		}
+	}
+
+	var err error
+	e.internalEnum, err = getInternalEnumForDecodingOrID[T](name, s != nil && s.scanNumeric)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetScanNumeric controls whether Scan resolves numeric values (strings or
+// byte slices with a base 10 integer, as returned by some drivers for integer
+// columns) as IDs when there is no enum with that name. This is disabled by
+// default as names always take precedence, which is ambiguous for enums with
+// numeric names.
+func SetScanNumeric[T constraints.Integer](numeric bool) {
+	getOrCreateSetForType[T]().scanNumeric = numeric
+}
+
+// SetSQLLabel sets a function used by Value to convert enums of type T to the
+// values written to the database, such as the labels of a Postgres enum type
+// that differ from the enum names. Scan matches values against the labels
+// returned by fn before falling back to the usual name lookup. Passing nil
+// restores the default of using names.
+func SetSQLLabel[T constraints.Integer](fn func(Enum[T]) string) {
+	getOrCreateSetForType[T]().sqlLabel = fn
+}
+
+// SetScanNullStrict controls whether Scan returns an error for NULL values
+// instead of leaving enums of type T unchanged. This is meant for NOT NULL
+// columns and is disabled by default.
+func SetScanNullStrict[T constraints.Integer](strict bool) {
+	getOrCreateSetForType[T]().scanNullStrict = strict
+}
+
+// SetScanCaseInsensitive controls whether Scan matches enum names (and
+// aliases) of type T case-insensitively when there is no exact match. This is
+// useful for databases with inconsistently cased data and is disabled by
+// default.
+func SetScanCaseInsensitive[T constraints.Integer](fold bool) {
+	getOrCreateSetForType[T]().scanFold = fold
+}
+
+// Set implements the flag.Value interface together with String.
+func (e *internalEnumWrapper[T]) Set(name string) error {
 	var err error
 	e.internalEnum, err = getInternalEnumForName[T](name)
 	if err != nil {
@@ -216,18 +1403,119 @@ func (e *internalEnumWrapper[T]) Scan(value any) error {
 	return nil
 }
 
-// String implements the fmt.Stringer interface.
+// Type returns the name of the enum type. Together with Set and String, it
+// implements the pflag.Value interface from github.com/spf13/pflag.
+func (e *internalEnumWrapper[T]) Type() string {
+	return getTypeName[T]()
+}
+
+// String implements the fmt.Stringer interface. It returns the name unless a
+// formatter was set with SetStringFormatter. Contrary to Name, it does not
+// panic for invalid enums and returns a sentinel including the type name
+// instead, so it is always safe to use while logging or formatting.
 func (e internalEnumWrapper[T]) String() string {
 	if !e.Valid() {
-		panic("enum not initialized")
+		return fmt.Sprintf("<invalid %s>", getTypeName[T]())
+	}
+
+	if s := getSetForType[T](); s != nil && s.stringFormatter != nil {
+		return s.stringFormatter(Enum[T]{e})
 	}
 
 	return e.name
 }
 
+// SetStringFormatter sets a function used by String (and, thus, by fmt) to
+// format valid enums of type T. Marshalling methods always use the name, so
+// this only affects presentation. Passing nil restores the default.
+func SetStringFormatter[T constraints.Integer](fn func(Enum[T]) string) {
+	getOrCreateSetForType[T]().stringFormatter = fn
+}
+
+// Verbose returns the name and ID of this Enum instance, like "Admin(1)", for
+// debugging. It ignores any formatter set with SetStringFormatter and returns
+// the same sentinel as String for invalid enums.
+func (e internalEnumWrapper[T]) Verbose() string {
+	if !e.Valid() {
+		return fmt.Sprintf("<invalid %s>", getTypeName[T]())
+	}
+
+	return fmt.Sprintf("%s(%d)", e.internalEnum.name, e.internalEnum.id)
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (e internalEnumWrapper[T]) GoString() string {
+	if !e.Valid() {
+		return fmt.Sprintf("enum.Enum[%s]{invalid}", getTypeName[T]())
+	}
+
+	return fmt.Sprintf("enum.Enum[%s](ID=%d, Name=%q)", getTypeName[T](), e.internalEnum.id, e.internalEnum.name)
+}
+
+// Format implements the fmt.Formatter interface. Integer verbs (%d, %b, %o,
+// %x and %X) print the ID, %s and %v print the same as String (the name,
+// unless a formatter was set with SetStringFormatter), %q prints the quoted
+// name and %#v prints the same as GoString. Other than for %#v, invalid enums
+// print the same sentinel as String for all verbs.
+func (e internalEnumWrapper[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		io.WriteString(f, e.GoString())
+		return
+	}
+
+	if !e.Valid() {
+		io.WriteString(f, e.String())
+		return
+	}
+
+	switch verb {
+	case 'd', 'b', 'o', 'x', 'X':
+		fmt.Fprintf(f, formatDirective(f, verb), e.internalEnum.id)
+	case 's', 'v':
+		fmt.Fprintf(f, formatDirective(f, verb), e.String())
+	case 'q':
+		fmt.Fprintf(f, formatDirective(f, verb), e.internalEnum.name)
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, e.String())
+	}
+}
+
+// formatDirective rebuilds the formatting directive (flags, width, precision
+// and verb) described by f and verb.
+func formatDirective(f fmt.State, verb rune) string {
+	var b strings.Builder
+
+	b.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+
+	if width, ok := f.Width(); ok {
+		b.WriteString(strconv.Itoa(width))
+	}
+
+	if precision, ok := f.Precision(); ok {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(precision))
+	}
+
+	b.WriteRune(verb)
+
+	return b.String()
+}
+
 // internalEnum is the internal representation of an Enum and is the type that
 // stores the Enum-associated data.
 type internalEnum[T constraints.Integer] struct {
-	name string
-	id   T
+	name        string
+	id          T
+	description string
+	label       string
+	deprecated  bool
+	meta        map[string]any
+
+	explicitID      bool // If true, the ID was given with WithID.
+	declaredAliases bool // If true, aliases were given with WithAliases.
 }
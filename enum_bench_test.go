@@ -0,0 +1,86 @@
+package enum
+
+import (
+	"fmt"
+	"testing"
+)
+
+type benchmarkEnum int
+
+func init() {
+	for i := 0; i < 100; i++ {
+		New[benchmarkEnum](fmt.Sprintf("Enum%d", i))
+	}
+}
+
+func BenchmarkEnumsByType(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		EnumsByType[benchmarkEnum]()
+	}
+}
+
+type registrationBenchmarkEnum int
+
+var registrationBenchmarkNames = func() []string {
+	names := make([]string, 1000)
+	for i := range names {
+		names[i] = fmt.Sprintf("Enum%d", i)
+	}
+
+	return names
+}()
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		for _, name := range registrationBenchmarkNames {
+			New[registrationBenchmarkEnum](name)
+		}
+
+		b.StopTimer()
+		Reset[registrationBenchmarkEnum]()
+		b.StartTimer()
+	}
+}
+
+var largeRegistrationBenchmarkNames = func() []string {
+	names := make([]string, 10000)
+	for i := range names {
+		names[i] = fmt.Sprintf("Enum%d", i)
+	}
+
+	return names
+}()
+
+func BenchmarkNew10k(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		for _, name := range largeRegistrationBenchmarkNames {
+			New[registrationBenchmarkEnum](name)
+		}
+
+		b.StopTimer()
+		Reset[registrationBenchmarkEnum]()
+		b.StartTimer()
+	}
+}
+
+func BenchmarkNew10kReserved(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		Reserve[registrationBenchmarkEnum](len(largeRegistrationBenchmarkNames))
+
+		for _, name := range largeRegistrationBenchmarkNames {
+			New[registrationBenchmarkEnum](name)
+		}
+
+		b.StopTimer()
+		Reset[registrationBenchmarkEnum]()
+		b.StartTimer()
+	}
+}
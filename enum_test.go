@@ -2,8 +2,19 @@ package enum
 
 import (
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+
+	"golang.org/x/exp/constraints"
 )
 
 // Old method role enum for reference.
@@ -199,3 +210,2158 @@ func TestEnum_EnumsForType(t *testing.T) {
 		t.Errorf("expected 4, got %d", len(enums))
 	}
 }
+
+func TestEnum_EnumsForUnregisteredType(t *testing.T) {
+	type unregisteredEnum int
+
+	enums := EnumsByType[unregisteredEnum]()
+	if enums == nil || len(enums) != 0 {
+		t.Errorf("expected empty slice, got %#v", enums)
+	}
+
+	if enums := VisibleEnumsByType[unregisteredEnum](); len(enums) != 0 {
+		t.Errorf("expected 0, got %d", len(enums))
+	}
+}
+
+func TestEnum_SortByID(t *testing.T) {
+	enums := EnumsByType[Role]()
+	sort.Sort(ByID[Role](enums))
+
+	expected := []RoleEnum{UnknownRole, Admin, User, Guest}
+	for i, e := range enums {
+		if RoleEnum(e) != expected[i] {
+			t.Errorf("expected %s at index %d, got %s", expected[i], i, e)
+		}
+	}
+}
+
+func TestEnum_SortByName(t *testing.T) {
+	enums := EnumsByType[Role]()
+	sort.Sort(ByName[Role](enums))
+
+	expected := []RoleEnum{Admin, Guest, UnknownRole, User}
+	for i, e := range enums {
+		if RoleEnum(e) != expected[i] {
+			t.Errorf("expected %s at index %d, got %s", expected[i], i, e)
+		}
+	}
+}
+
+func TestSorted(t *testing.T) {
+	// By descending name length. Ties keep their ID order.
+	enums := Sorted(func(a, b Enum[Role]) bool {
+		return len(a.Name()) > len(b.Name())
+	})
+
+	expected := []RoleEnum{UnknownRole, Admin, Guest, User}
+	for i, e := range enums {
+		if RoleEnum(e) != expected[i] {
+			t.Errorf("expected %s at index %d, got %s", expected[i], i, e)
+		}
+	}
+
+	if e := EnumsByType[Role]()[1]; RoleEnum(e) != Admin {
+		t.Errorf("expected %s at index 1, got %s", Admin, e)
+	}
+
+	type unregisteredEnum int
+
+	if enums := Sorted(func(a, b Enum[unregisteredEnum]) bool { return true }); enums == nil || len(enums) != 0 {
+		t.Errorf("expected empty slice, got %v", enums)
+	}
+}
+
+func TestEnum_IsZero(t *testing.T) {
+	var zero RoleEnum
+	if !zero.IsZero() {
+		t.Errorf("expected zero value to be zero")
+	}
+
+	if Admin.IsZero() {
+		t.Errorf("expected %s to not be zero", Admin)
+	}
+}
+
+func TestEnum_UnmarshalTextLineTerminator(t *testing.T) {
+	for _, text := range []string{"Admin\n", "Admin\r\n"} {
+		var role RoleEnum
+		if err := role.UnmarshalText([]byte(text)); err != nil {
+			t.Fatalf("unexpected error for %q: %s", text, err)
+		}
+
+		if role != Admin {
+			t.Errorf("expected %s for %q, got %s", Admin, text, role)
+		}
+	}
+}
+
+func TestEnum_UnmarshalTextStrict(t *testing.T) {
+	type strictEnum int
+
+	New[strictEnum]("Admin")
+	SetStrictText[strictEnum](true)
+
+	var e Enum[strictEnum]
+	if err := e.UnmarshalText([]byte("Admin\n")); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	if err := e.UnmarshalText([]byte("Admin")); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestEnum_StringInvalid(t *testing.T) {
+	expected := "<invalid " + getTypeName[Role]() + ">"
+
+	if s := fmt.Sprint(Enum[Role]{}); s != expected {
+		t.Errorf("expected %s, got %s", expected, s)
+	}
+}
+
+func TestEnum_NameOrIDOr(t *testing.T) {
+	var zero RoleEnum
+
+	if name := zero.NameOr("none"); name != "none" {
+		t.Errorf("expected none, got %s", name)
+	}
+	if id := zero.IDOr(-1); id != -1 {
+		t.Errorf("expected -1, got %d", id)
+	}
+
+	if name := Admin.NameOr("none"); name != Admin.Name() {
+		t.Errorf("expected %s, got %s", Admin.Name(), name)
+	}
+	if id := Admin.IDOr(-1); id != Admin.ID() {
+		t.Errorf("expected %d, got %d", Admin.ID(), id)
+	}
+}
+
+func TestEnum_Validate(t *testing.T) {
+	var zero RoleEnum
+	if err := zero.Validate(); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	if err := Admin.Validate(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	e, err := Resolve[Role]("Admin")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if RoleEnum(e) != Admin {
+		t.Errorf("expected %s, got %s", Admin, e)
+	}
+
+	e, err = Resolve[Role]("2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if RoleEnum(e) != User {
+		t.Errorf("expected %s, got %s", User, e)
+	}
+
+	_, err = Resolve[Role]("Owner")
+
+	var lookupErr *LookupError
+	if !errors.As(err, &lookupErr) || lookupErr.Name != "Owner" || !errors.Is(err, ErrUnknownName) {
+		t.Errorf("expected %v for Owner, got %v", ErrUnknownName, err)
+	}
+
+	if err != nil && strings.Contains(err.Error(), "strconv") {
+		t.Errorf("expected no parse error in %q", err)
+	}
+
+	if _, err = Resolve[Role]("42"); !errors.Is(err, ErrUnknownName) || !strings.Contains(err.Error(), "as an ID") {
+		t.Errorf("expected %v mentioning the ID, got %v", ErrUnknownName, err)
+	}
+}
+
+func TestResolve_Any(t *testing.T) {
+	type namedString string
+
+	for _, v := range []any{"User", []byte("User"), "2", json.Number("2"), namedString("User"), Role(2), 2, int8(2), uint64(2)} {
+		if e, err := Resolve[Role](v); err != nil || RoleEnum(e) != User {
+			t.Errorf("expected %s for %#v, got %s (error %v)", User, v, e, err)
+		}
+	}
+
+	for _, v := range []any{42, -1, uint64(math.MaxUint64), int64(math.MaxInt64)} {
+		if _, err := Resolve[Role](v); err == nil {
+			t.Errorf("expected error for %#v, got nil", v)
+		}
+	}
+
+	for _, v := range []any{nil, 2.0, true, struct{}{}} {
+		if _, err := Resolve[Role](v); err == nil || !strings.Contains(err.Error(), "unsupported type") {
+			t.Errorf("expected unsupported type error for %#v, got %v", v, err)
+		}
+	}
+
+	type byteEnum uint8
+
+	zero := New[byteEnum]("Zero")
+
+	if _, err := Resolve[byteEnum](256); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("expected out of range error, got %v", err)
+	}
+
+	if e, err := Resolve[byteEnum](uint(0)); err != nil || e != zero {
+		t.Errorf("expected %s, got %s (error %v)", zero, e, err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	type internalRole int
+	type apiRole uint8
+
+	internalAdmin := New[internalRole]("Admin")
+	internalOwner := New[internalRole]("Owner")
+	New[apiRole]("Guest")
+	apiAdmin := New[apiRole]("Admin")
+
+	converted, err := Convert[internalRole, apiRole](internalAdmin)
+	if err != nil || converted != apiAdmin {
+		t.Errorf("expected %s, got %s (error %v)", apiAdmin, converted, err)
+	}
+
+	if _, err := Convert[internalRole, apiRole](internalOwner); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("expected %v, got %v", ErrUnknownName, err)
+	}
+
+	if _, err := Convert[internalRole, apiRole](Enum[internalRole]{}); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestResolveAll(t *testing.T) {
+	enums, err := ResolveAll[Role]([]string{"Admin", "2", "Guest"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []RoleEnum{Admin, User, Guest}
+	if len(enums) != len(expected) {
+		t.Fatalf("expected %d enums, got %d", len(expected), len(enums))
+	}
+
+	for i, e := range enums {
+		if RoleEnum(e) != expected[i] {
+			t.Errorf("expected %s at index %d, got %s", expected[i], i, e)
+		}
+	}
+
+	enums, err = ResolveAll[Role]([]string{"Owner", "Admin", "42"})
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "index 0: Owner ") || !strings.HasPrefix(lines[1], "index 2: 42 ") {
+		t.Errorf("expected errors for indexes 0 and 2, got %q", err)
+	}
+
+	if !errors.Is(err, ErrUnknownName) {
+		t.Errorf("expected %v, got %v", ErrUnknownName, err)
+	}
+
+	if len(enums) != 3 || enums[0].Valid() || RoleEnum(enums[1]) != Admin || enums[2].Valid() {
+		t.Errorf("expected partial results [<invalid> %s <invalid>], got %v", Admin, enums)
+	}
+
+	if enums, err := ResolveAll[Role](nil); err != nil || len(enums) != 0 {
+		t.Errorf("expected no enums and no error, got %v (error %v)", enums, err)
+	}
+}
+
+// overflowIteration registers enums of type T until it panics and returns the
+// iteration where the panic happened, or -1 if it never panics.
+func overflowIteration[T constraints.Integer](limit int) (iteration int) {
+	defer func() {
+		recover()
+	}()
+
+	for ; iteration < limit; iteration++ {
+		New[T](fmt.Sprintf("Enum%d", iteration))
+	}
+
+	return -1
+}
+
+func TestEnum_OverflowBoundary(t *testing.T) {
+	type int8BoundaryEnum int8
+	type uint8BoundaryEnum uint8
+
+	if i := overflowIteration[int8BoundaryEnum](1000); i != 128 {
+		t.Errorf("expected int8 panic at iteration 128, got %d", i)
+	}
+
+	if i := overflowIteration[uint8BoundaryEnum](1000); i != 256 {
+		t.Errorf("expected uint8 panic at iteration 256, got %d", i)
+	}
+}
+
+func registerLocalEnumA() int {
+	type localEnum int
+
+	New[localEnum]("Local")
+
+	return len(EnumsByType[localEnum]())
+}
+
+func registerLocalEnumB() int {
+	type localEnum int
+
+	New[localEnum]("Local")
+
+	return len(EnumsByType[localEnum]())
+}
+
+func TestEnum_LocalTypes(t *testing.T) {
+	// Both functions declare a local type with the same name. They must not
+	// share a set, or the second registration would panic as a duplicate.
+	if n := registerLocalEnumA(); n != 1 {
+		t.Errorf("expected 1, got %d", n)
+	}
+
+	if n := registerLocalEnumB(); n != 1 {
+		t.Errorf("expected 1, got %d", n)
+	}
+}
+
+func TestEnum_Freeze(t *testing.T) {
+	type frozenEnum int
+
+	New[frozenEnum]("One")
+
+	if IsFrozen[frozenEnum]() {
+		t.Fatalf("expected set to not be frozen")
+	}
+
+	Freeze[frozenEnum]()
+
+	if !IsFrozen[frozenEnum]() {
+		t.Fatalf("expected set to be frozen")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	New[frozenEnum]("Two")
+}
+
+func TestEnum_FreezeConcurrent(t *testing.T) {
+	type concurrentFrozenEnum int
+
+	// Each iteration starts from an unfrozen set, so Freeze can land at any
+	// point of TryNewMany.
+	for i := 0; i < 20000; i++ {
+		Reset[concurrentFrozenEnum]()
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Freeze[concurrentFrozenEnum]()
+		}()
+
+		enums, err := TryNewMany[concurrentFrozenEnum]("One", "Two", "Three")
+		if err != nil && !errors.Is(err, errFrozenSet) {
+			t.Errorf("expected nil or %v, got %v", errFrozenSet, err)
+		}
+
+		wg.Wait()
+
+		if !IsFrozen[concurrentFrozenEnum]() {
+			t.Errorf("expected set to be frozen")
+		}
+
+		if count := Count[concurrentFrozenEnum](); count != len(enums) {
+			t.Fatalf("expected %d enums to be registered, got %d", len(enums), count)
+		}
+	}
+}
+
+func TestEnum_Reset(t *testing.T) {
+	type resetEnum int
+
+	one := New[resetEnum]("One")
+
+	Reset[resetEnum]()
+
+	if _, err := EnumByTypeAndName[resetEnum]("One"); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	// Registering the same name again must not panic and IDs restart from 0.
+	newOne := New[resetEnum]("One")
+	if newOne.ID() != one.ID() {
+		t.Errorf("expected ID %d, got %d", one.ID(), newOne.ID())
+	}
+}
+
+func TestEnum_Description(t *testing.T) {
+	type describedEnum int
+
+	plain := New[describedEnum]("Plain")
+	described := NewWithDescription[describedEnum]("Described", "An enum with a description")
+
+	if d := plain.Description(); d != "" {
+		t.Errorf("expected empty description, got %s", d)
+	}
+
+	if d := described.Description(); d != "An enum with a description" {
+		t.Errorf("expected %q, got %q", "An enum with a description", d)
+	}
+}
+
+func TestEnum_Label(t *testing.T) {
+	type labeledEnum int
+
+	plain := New[labeledEnum]("Plain")
+	labeled := NewWithLabel[labeledEnum]("ReadOnly", "Read Only")
+
+	if l := plain.Label(); l != "Plain" {
+		t.Errorf("expected Plain, got %s", l)
+	}
+
+	if l := labeled.Label(); l != "Read Only" {
+		t.Errorf("expected Read Only, got %s", l)
+	}
+
+	data, err := json.Marshal(labeled)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if string(data) != `"ReadOnly"` {
+		t.Errorf("expected \"ReadOnly\", got %s", data)
+	}
+}
+
+func TestEnum_AddAlias(t *testing.T) {
+	type aliasedEnum int
+
+	admin := New[aliasedEnum]("Admin")
+	admin.AddAlias("sysadmin")
+
+	e, err := EnumByTypeAndName[aliasedEnum]("sysadmin")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if e != admin {
+		t.Errorf("expected %s, got %s", admin, e)
+	}
+	if e.Name() != "Admin" {
+		t.Errorf("expected Admin, got %s", e.Name())
+	}
+
+	if n := len(EnumsByType[aliasedEnum]()); n != 1 {
+		t.Errorf("expected 1, got %d", n)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	admin.AddAlias("sysadmin")
+}
+
+func TestEnum_AddAliasFrozen(t *testing.T) {
+	type frozenAliasedEnum int
+
+	admin := New[frozenAliasedEnum]("Admin")
+	Freeze[frozenAliasedEnum]()
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("expected panic, got normal execution")
+			}
+		}()
+
+		admin.AddAlias("root")
+	}()
+
+	if IsValidName[frozenAliasedEnum]("root") {
+		t.Errorf("expected root to not be registered")
+	}
+}
+
+func TestEnum_Deprecate(t *testing.T) {
+	type deprecatedEnum int
+
+	current := New[deprecatedEnum]("Current")
+	legacy := New[deprecatedEnum]("Legacy")
+
+	legacy.Deprecate()
+
+	if current.IsDeprecated() {
+		t.Errorf("expected %s to not be deprecated", current)
+	}
+	if !legacy.IsDeprecated() {
+		t.Errorf("expected %s to be deprecated", legacy)
+	}
+
+	visible := VisibleEnumsByType[deprecatedEnum]()
+	if len(visible) != 1 || visible[0] != current {
+		t.Errorf("expected [%s], got %v", current, visible)
+	}
+
+	if n := len(EnumsByType[deprecatedEnum]()); n != 2 {
+		t.Errorf("expected 2, got %d", n)
+	}
+}
+
+func TestEnum_Meta(t *testing.T) {
+	type metaEnum int
+
+	e := New[metaEnum]("WithMeta")
+
+	if _, ok := e.Meta("color"); ok {
+		t.Errorf("expected no metadata")
+	}
+
+	e.SetMeta("color", "red")
+
+	value, ok := e.Meta("color")
+	if !ok {
+		t.Fatalf("expected metadata")
+	}
+	if value != "red" {
+		t.Errorf("expected red, got %v", value)
+	}
+}
+
+func TestEnum_MetaConcurrent(t *testing.T) {
+	type concurrentMetaEnum int
+
+	e := New[concurrentMetaEnum]("WithMeta")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			e.SetMeta(fmt.Sprintf("key%d", i), i)
+			e.Meta("key0")
+			e.Deprecate()
+			e.Definition()
+		}(i)
+	}
+
+	wg.Wait()
+
+	if value, ok := e.Meta("key3"); !ok || value != 3 {
+		t.Errorf("expected 3, got %v", value)
+	}
+}
+
+func TestEnum_Flag(t *testing.T) {
+	var role RoleEnum
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&role, "role", "the role")
+
+	if err := fs.Parse([]string{"-role", "Guest"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if role != Guest {
+		t.Errorf("expected %s, got %s", Guest, role)
+	}
+
+	if err := fs.Parse([]string{"-role", "Owner"}); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestEnum_UnknownFallback(t *testing.T) {
+	type fallbackEnum int
+
+	unknown := New[fallbackEnum]("Unknown")
+	known := New[fallbackEnum]("Known")
+
+	var e Enum[fallbackEnum]
+	if err := json.Unmarshal([]byte(`"New"`), &e); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	SetUnknownFallback(unknown)
+
+	if err := json.Unmarshal([]byte(`"New"`), &e); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if e != unknown {
+		t.Errorf("expected %s, got %s", unknown, e)
+	}
+
+	if err := e.UnmarshalText([]byte("New")); err != nil || e != unknown {
+		t.Errorf("expected %s, got %s (error %v)", unknown, e, err)
+	}
+
+	if err := e.Scan("New"); err != nil || e != unknown {
+		t.Errorf("expected %s, got %s (error %v)", unknown, e, err)
+	}
+
+	if err := e.Scan("Known"); err != nil || e != known {
+		t.Errorf("expected %s, got %s (error %v)", known, e, err)
+	}
+}
+
+func TestRegisteredTypes(t *testing.T) {
+	typeNames := RegisteredTypes()
+
+	if !sort.StringsAreSorted(typeNames) {
+		t.Errorf("expected sorted type names, got %v", typeNames)
+	}
+
+	found := false
+	for _, typeName := range typeNames {
+		if typeName == getTypeName[Permission]() {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("expected %s in %v", getTypeName[Permission](), typeNames)
+	}
+}
+
+func TestNamesForType(t *testing.T) {
+	names, err := NamesForType(getTypeName[Permission]())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{"Unknown", "Read", "Write"}
+	if fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+
+	if _, err := NamesForType("not.Registered"); !errors.Is(err, ErrTypeNotRegistered) {
+		t.Errorf("expected ErrTypeNotRegistered, got %v", err)
+	}
+}
+
+func TestEnum_Format(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%d", "1"},
+		{"%03d", "001"},
+		{"%s", "Admin"},
+		{"%v", "Admin"},
+		{"%q", `"Admin"`},
+		{"%-7s|", "Admin  |"},
+	}
+
+	for _, test := range tests {
+		if s := fmt.Sprintf(test.format, Admin); s != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.format, test.expected, s)
+		}
+	}
+
+	var zero RoleEnum
+
+	expected := "<invalid " + getTypeName[Role]() + ">"
+	for _, format := range []string{"%d", "%s", "%v", "%q"} {
+		if s := fmt.Sprintf(format, zero); s != expected {
+			t.Errorf("%s: expected %s, got %s", format, expected, s)
+		}
+	}
+}
+
+func TestEnum_UnmarshalJSONNumber(t *testing.T) {
+	var role RoleEnum
+	if err := json.Unmarshal([]byte(`1`), &role); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if role != Admin {
+		t.Errorf("expected %s, got %s", Admin, role)
+	}
+
+	if err := json.Unmarshal([]byte(`42`), &role); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected ErrUnknownID, got %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`1.5`), &role); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestEnum_UnmarshalJSONTrimSpace(t *testing.T) {
+	type trimmedEnum int
+
+	admin := New[trimmedEnum]("Admin")
+
+	var e Enum[trimmedEnum]
+	if err := json.Unmarshal([]byte(`"  Admin "`), &e); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	SetTrimSpace[trimmedEnum](true)
+
+	if err := json.Unmarshal([]byte(`"  Admin "`), &e); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if e != admin {
+		t.Errorf("expected %s, got %s", admin, e)
+	}
+}
+
+func TestEnum_Clone(t *testing.T) {
+	clone := Admin.Clone()
+	if RoleEnum(clone) != Admin {
+		t.Errorf("expected %s, got %s", Admin, clone)
+	}
+
+	var zero RoleEnum
+	if clone := zero.Clone(); clone.Valid() {
+		t.Errorf("expected invalid clone, got %s", clone)
+	}
+}
+
+func TestEnum_GoString(t *testing.T) {
+	expected := `enum.Enum[` + getTypeName[Role]() + `](ID=1, Name="Admin")`
+	if s := fmt.Sprintf("%#v", Admin); s != expected {
+		t.Errorf("expected %s, got %s", expected, s)
+	}
+
+	var zero RoleEnum
+
+	expected = `enum.Enum[` + getTypeName[Role]() + `]{invalid}`
+	if s := fmt.Sprintf("%#v", zero); s != expected {
+		t.Errorf("expected %s, got %s", expected, s)
+	}
+}
+
+func TestNewMany(t *testing.T) {
+	type manyEnum int
+
+	enums := NewMany[manyEnum]("Zero", "One", "Two")
+	for i, e := range enums {
+		if int(e.ID()) != i {
+			t.Errorf("expected ID %d, got %d", i, e.ID())
+		}
+	}
+
+	if _, err := TryNewMany[manyEnum]("Three", "Three"); err == nil || !strings.Contains(err.Error(), "index 1") {
+		t.Errorf("expected error for index 1, got %v", err)
+	}
+
+	if _, err := TryNewMany[manyEnum]("Three", ""); err == nil || !strings.Contains(err.Error(), "index 1") {
+		t.Errorf("expected error for index 1, got %v", err)
+	}
+
+	// Failed calls must not register anything.
+	if n := len(EnumsByType[manyEnum]()); n != 3 {
+		t.Errorf("expected 3, got %d", n)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	NewMany[manyEnum]("One")
+}
+
+func TestTryNewMany_TooMany(t *testing.T) {
+	type tooManyEnum uint8
+
+	names := make([]string, 257)
+	for i := range names {
+		names[i] = fmt.Sprintf("Enum%d", i)
+	}
+
+	if _, err := TryNewMany[tooManyEnum](names...); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	if _, err := TryNewMany[tooManyEnum](names[:256]...); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestValidateContiguous(t *testing.T) {
+	if err := ValidateContiguous[Role](); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	type unregisteredEnum int
+
+	if err := ValidateContiguous[unregisteredEnum](); !errors.Is(err, ErrTypeNotRegistered) {
+		t.Errorf("expected ErrTypeNotRegistered, got %v", err)
+	}
+
+	type configuredEnum int
+
+	SetEncoding[configuredEnum](EncodeID)
+
+	if err := ValidateContiguous[configuredEnum](); !errors.Is(err, ErrTypeNotRegistered) {
+		t.Errorf("expected ErrTypeNotRegistered for empty set, got %v", err)
+	}
+
+	type gappedEnum int
+
+	New[gappedEnum]("Zero")
+	New[gappedEnum]("One")
+
+	NewWithID[gappedEnum]("Three", 3)
+
+	if err := ValidateContiguous[gappedEnum](); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	// Explicit IDs can not be duplicated, so we need to force it. As this leaves
+	// the set in an otherwise impossible state, remove it afterwards.
+	defer Reset[gappedEnum]()
+	getSetForType[gappedEnum]().insert(&internalEnum[gappedEnum]{name: "OtherOne", id: 1})
+
+	if err := ValidateContiguous[gappedEnum](); err == nil || !strings.Contains(err.Error(), "same ID") {
+		t.Errorf("expected duplicate ID error, got %v", err)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	var visited []RoleEnum
+	ForEach(func(e Enum[Role]) bool {
+		visited = append(visited, RoleEnum(e))
+		return RoleEnum(e) != User
+	})
+
+	expected := []RoleEnum{UnknownRole, Admin, User}
+	if fmt.Sprint(visited) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, visited)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	enums := Filter(func(e Enum[Role]) bool {
+		return RoleEnum(e) != UnknownRole && RoleEnum(e) != Guest
+	})
+
+	expected := []RoleEnum{Admin, User}
+	if fmt.Sprint(enums) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, enums)
+	}
+
+	type unregisteredEnum int
+
+	enums2 := Filter(func(Enum[unregisteredEnum]) bool { return true })
+	if enums2 == nil || len(enums2) != 0 {
+		t.Errorf("expected empty slice, got %v", enums2)
+	}
+}
+
+func TestWithUnknown(t *testing.T) {
+	type withUnknownEnum int
+
+	unknown := WithUnknown[withUnknownEnum]("Unknown")
+	New[withUnknownEnum]("Known")
+
+	if unknown.ID() != 0 {
+		t.Errorf("expected ID 0, got %d", unknown.ID())
+	}
+
+	var e Enum[withUnknownEnum]
+	if err := json.Unmarshal([]byte(`"New"`), &e); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if e != unknown {
+		t.Errorf("expected %s, got %s", unknown, e)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	WithUnknown[withUnknownEnum]("OtherUnknown")
+}
+
+func TestWithUnknown_AfterUnregister(t *testing.T) {
+	type unregisteredUnknownEnum int
+
+	New[unregisteredUnknownEnum]("First")
+	if err := Unregister[unregisteredUnknownEnum]("First"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	WithUnknown[unregisteredUnknownEnum]("Unknown")
+}
+
+func TestIsValidIDAndName(t *testing.T) {
+	if !IsValidID[Role](Guest.ID()) {
+		t.Errorf("expected ID %d to be valid", Guest.ID())
+	}
+	if IsValidID[Role](42) {
+		t.Errorf("expected ID 42 to be invalid")
+	}
+
+	if !IsValidName[Role]("Guest") {
+		t.Errorf("expected name Guest to be valid")
+	}
+	if IsValidName[Role]("Owner") {
+		t.Errorf("expected name Owner to be invalid")
+	}
+
+	type unregisteredEnum int
+
+	if IsValidID[unregisteredEnum](0) || IsValidName[unregisteredEnum]("Zero") {
+		t.Errorf("expected unregistered type to have no valid IDs or names")
+	}
+}
+
+func TestIsValidID_Allocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		IsValidID[Role](42)
+	})
+
+	if allocs != 0 {
+		t.Errorf("expected 0 allocations, got %v", allocs)
+	}
+}
+
+func TestNewWithID(t *testing.T) {
+	type explicitEnum int
+
+	ten := NewWithID[explicitEnum]("Ten", 10)
+	five := NewWithID[explicitEnum]("Five", 5)
+
+	if ten.ID() != 10 || five.ID() != 5 {
+		t.Errorf("expected IDs 10 and 5, got %d and %d", ten.ID(), five.ID())
+	}
+
+	e, err := EnumByTypeAndID[explicitEnum](10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if e != ten {
+		t.Errorf("expected %s, got %s", ten, e)
+	}
+
+	var visited []Enum[explicitEnum]
+	ForEach(func(e Enum[explicitEnum]) bool {
+		visited = append(visited, e)
+		return true
+	})
+
+	if len(visited) != 2 || visited[0] != five || visited[1] != ten {
+		t.Errorf("expected [%s %s], got %v", five, ten, visited)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	NewWithID[explicitEnum]("OtherTen", 10)
+}
+
+func TestEnum_Ordinal(t *testing.T) {
+	if o := Guest.Ordinal(); o != int(Guest.ID()) {
+		t.Errorf("expected %d, got %d", Guest.ID(), o)
+	}
+
+	type gappedOrdinalEnum int
+
+	hundred := NewWithID[gappedOrdinalEnum]("Hundred", 100)
+	ten := NewWithID[gappedOrdinalEnum]("Ten", 10)
+
+	if o := ten.Ordinal(); o != 0 {
+		t.Errorf("expected 0, got %d", o)
+	}
+	if o := hundred.Ordinal(); o != 1 {
+		t.Errorf("expected 1, got %d", o)
+	}
+}
+
+func TestCeilingFloor(t *testing.T) {
+	type sparseEnum int
+
+	ten := NewWithID[sparseEnum]("Ten", 10)
+	twenty := NewWithID[sparseEnum]("Twenty", 20)
+
+	tests := []struct {
+		id             sparseEnum
+		ceiling, floor Enum[sparseEnum]
+	}{
+		{5, ten, Enum[sparseEnum]{}},
+		{10, ten, ten},
+		{15, twenty, ten},
+		{20, twenty, twenty},
+		{25, Enum[sparseEnum]{}, twenty},
+	}
+
+	for _, test := range tests {
+		if e, ok := Ceiling(test.id); e != test.ceiling || ok != test.ceiling.Valid() {
+			t.Errorf("expected ceiling %s for %d, got %s (%t)", test.ceiling, test.id, e, ok)
+		}
+		if e, ok := Floor(test.id); e != test.floor || ok != test.floor.Valid() {
+			t.Errorf("expected floor %s for %d, got %s (%t)", test.floor, test.id, e, ok)
+		}
+	}
+
+	type unregisteredSparseEnum int
+
+	if _, ok := Ceiling[unregisteredSparseEnum](0); ok {
+		t.Errorf("expected no ceiling for unregistered type")
+	}
+	if _, ok := Floor[unregisteredSparseEnum](0); ok {
+		t.Errorf("expected no floor for unregistered type")
+	}
+}
+
+func TestHigher(t *testing.T) {
+	type boundedEnum int8
+
+	lowest := NewWithID[boundedEnum]("Min", math.MinInt8)
+	zero := NewWithID[boundedEnum]("Zero", 0)
+	highest := NewWithID[boundedEnum]("Max", math.MaxInt8)
+
+	var visited []Enum[boundedEnum]
+	for e, ok := Ceiling[boundedEnum](math.MinInt8); ok; e, ok = Higher(e.ID()) {
+		visited = append(visited, e)
+
+		if len(visited) > 3 {
+			t.Fatalf("expected iteration to stop after %s, got %v", highest, visited)
+		}
+	}
+
+	if len(visited) != 3 || visited[0] != lowest || visited[1] != zero || visited[2] != highest {
+		t.Errorf("expected [%s %s %s], got %v", lowest, zero, highest, visited)
+	}
+
+	if e, ok := Higher[boundedEnum](-1); !ok || e != zero {
+		t.Errorf("expected %s, got %s (%t)", zero, e, ok)
+	}
+
+	type unregisteredHigherEnum int
+
+	if _, ok := Higher[unregisteredHigherEnum](0); ok {
+		t.Errorf("expected no higher enum for unregistered type")
+	}
+}
+
+func TestEnum_IsFirstIsLast(t *testing.T) {
+	type boundaryEnum int
+
+	hundred := NewWithID[boundaryEnum]("Hundred", 100)
+	ten := NewWithID[boundaryEnum]("Ten", 10)
+	fifty := NewWithID[boundaryEnum]("Fifty", 50)
+
+	tests := []struct {
+		e           Enum[boundaryEnum]
+		first, last bool
+	}{
+		{ten, true, false},
+		{fifty, false, false},
+		{hundred, false, true},
+		{Enum[boundaryEnum]{}, false, false},
+	}
+
+	for _, test := range tests {
+		if first := test.e.IsFirst(); first != test.first {
+			t.Errorf("expected IsFirst %t for %s, got %t", test.first, test.e, first)
+		}
+		if last := test.e.IsLast(); last != test.last {
+			t.Errorf("expected IsLast %t for %s, got %t", test.last, test.e, last)
+		}
+	}
+
+	type singleEnum int
+
+	only := New[singleEnum]("Only")
+	if !only.IsFirst() || !only.IsLast() {
+		t.Errorf("expected single enum to be both first and last")
+	}
+}
+
+func TestEnum_DecodingEquality(t *testing.T) {
+	decoders := map[string]func(*RoleEnum) error{
+		"UnmarshalJSON": func(e *RoleEnum) error { return json.Unmarshal([]byte(`"Guest"`), e) },
+		"UnmarshalJSON number": func(e *RoleEnum) error {
+			return json.Unmarshal([]byte(strconv.Itoa(int(Guest.ID()))), e)
+		},
+		"UnmarshalText": func(e *RoleEnum) error { return e.UnmarshalText([]byte("Guest")) },
+		"Scan string":   func(e *RoleEnum) error { return e.Scan("Guest") },
+		"Scan bytes":    func(e *RoleEnum) error { return e.Scan([]byte("Guest")) },
+		"Set":           func(e *RoleEnum) error { return e.Set("Guest") },
+	}
+
+	for name, decode := range decoders {
+		var role RoleEnum
+		if err := decode(&role); err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+
+		if role != Guest {
+			t.Errorf("%s: expected internalEnum pointer %p, got %p", name, Guest.internalEnum, role.internalEnum)
+		}
+
+		switch role {
+		case Guest:
+			// Just do not error out. This is what we want.
+		default:
+			t.Errorf("%s: expected switch to match %s", name, Guest)
+		}
+	}
+}
+
+func TestMustGet(t *testing.T) {
+	if e := MustGet[Role]("User"); RoleEnum(e) != User {
+		t.Errorf("expected %s, got %s", User, e)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	MustGet[Role]("Owner")
+}
+
+func TestMustEnumByTypeAndID(t *testing.T) {
+	if e := MustEnumByTypeAndID(User.ID()); RoleEnum(e) != User {
+		t.Errorf("expected %s, got %s", User, e)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	MustEnumByTypeAndID[Role](42)
+}
+
+func TestEnum_In(t *testing.T) {
+	options := []Enum[Role]{Enum[Role](Admin), Enum[Role](User)}
+
+	if !User.In(options...) {
+		t.Errorf("expected %s to be in %v", User, options)
+	}
+	if Guest.In(options...) {
+		t.Errorf("expected %s to not be in %v", Guest, options)
+	}
+
+	var zero RoleEnum
+	if zero.In(options...) || zero.In(Enum[Role]{}) {
+		t.Errorf("expected invalid enum to not be in any options")
+	}
+}
+
+func TestNewWithID_Negative(t *testing.T) {
+	type signedEnum int16
+
+	errorEnum := NewWithID[signedEnum]("Error", -1)
+	minEnum := NewWithID[signedEnum]("Min", math.MinInt16)
+	maxEnum := NewWithID[signedEnum]("Max", math.MaxInt16)
+	zero := New[signedEnum]("Zero")
+
+	if zero.ID() != 0 {
+		t.Errorf("expected ID 0, got %d", zero.ID())
+	}
+
+	for _, expected := range []Enum[signedEnum]{errorEnum, minEnum, maxEnum, zero} {
+		e, err := EnumByTypeAndID(expected.ID())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if e != expected {
+			t.Errorf("expected %s, got %s", expected, e)
+		}
+	}
+
+	if e, err := Resolve[signedEnum]("-1"); err != nil || e != errorEnum {
+		t.Errorf("expected %s, got %s (error %v)", errorEnum, e, err)
+	}
+
+	var e Enum[signedEnum]
+	if err := json.Unmarshal([]byte(`-32768`), &e); err != nil || e != minEnum {
+		t.Errorf("expected %s, got %s (error %v)", minEnum, e, err)
+	}
+
+	if o := minEnum.Ordinal(); o != 0 {
+		t.Errorf("expected ordinal 0, got %d", o)
+	}
+	if o := maxEnum.Ordinal(); o != 3 {
+		t.Errorf("expected ordinal 3, got %d", o)
+	}
+
+	// Explicit IDs must not affect the auto-generated ID capacity.
+	if remaining := getSetForType[signedEnum]().Remaining(); remaining != math.MaxInt16 {
+		t.Errorf("expected %d remaining IDs, got %d", math.MaxInt16, remaining)
+	}
+}
+
+func TestEnumsByType_Copy(t *testing.T) {
+	enums := EnumsByType[Role]()
+	enums[0] = Enum[Role](Guest)
+
+	if e := EnumsByType[Role]()[0]; RoleEnum(e) != UnknownRole {
+		t.Errorf("expected %s, got %s", UnknownRole, e)
+	}
+
+	type growingEnum int
+
+	New[growingEnum]("One")
+	if n := len(EnumsByType[growingEnum]()); n != 1 {
+		t.Errorf("expected 1, got %d", n)
+	}
+
+	New[growingEnum]("Two")
+	if n := len(EnumsByType[growingEnum]()); n != 2 {
+		t.Errorf("expected 2, got %d", n)
+	}
+}
+
+func TestSetStringFormatter(t *testing.T) {
+	type formattedEnum int
+
+	admin := New[formattedEnum]("Admin")
+
+	SetStringFormatter(func(e Enum[formattedEnum]) string {
+		return "role:" + strings.ToLower(e.Name())
+	})
+
+	if s := admin.String(); s != "role:admin" {
+		t.Errorf("expected role:admin, got %s", s)
+	}
+	if s := fmt.Sprintf("%v", admin); s != "role:admin" {
+		t.Errorf("expected role:admin, got %s", s)
+	}
+	if s := fmt.Sprintf("%q", admin); s != `"Admin"` {
+		t.Errorf(`expected "Admin", got %s`, s)
+	}
+
+	data, err := json.Marshal(admin)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(data) != `"Admin"` {
+		t.Errorf(`expected "Admin", got %s`, data)
+	}
+
+	if text, _ := admin.MarshalText(); string(text) != "Admin" {
+		t.Errorf("expected Admin, got %s", text)
+	}
+}
+
+func TestEnum_MixedIDCollision(t *testing.T) {
+	type mixedEnum int
+
+	New[mixedEnum]("Zero")
+	NewWithID[mixedEnum]("Explicit", 1)
+
+	if _, err := TryNewMany[mixedEnum]("One"); err == nil || !strings.Contains(err.Error(), "Explicit") {
+		t.Errorf("expected error naming Explicit, got %v", err)
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatalf("expected panic, got normal execution")
+		}
+
+		if msg := fmt.Sprint(r); !strings.Contains(msg, "One") || !strings.Contains(msg, "Explicit") {
+			t.Errorf("expected panic message naming both enums, got %s", msg)
+		}
+	}()
+
+	New[mixedEnum]("One")
+}
+
+func TestEnum_MixedIDCollisionRetry(t *testing.T) {
+	type retriedEnum int
+
+	NewWithID[retriedEnum]("Explicit", 0)
+
+	for i := 0; i < 2; i++ {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic, got normal execution")
+				}
+			}()
+
+			New[retriedEnum]("One")
+		}()
+	}
+
+	// Failed attempts must not consume the auto-generated ID.
+	if err := Unregister[retriedEnum]("Explicit"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if e := New[retriedEnum]("One"); e.ID() != 0 {
+		t.Errorf("expected ID 0, got %d", e.ID())
+	}
+}
+
+func TestNewWithID_CollidesWithAuto(t *testing.T) {
+	type mixedEnum int
+
+	New[mixedEnum]("Zero")
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatalf("expected panic, got normal execution")
+		}
+
+		if msg := fmt.Sprint(r); !strings.Contains(msg, "Zero") || !strings.Contains(msg, "Explicit") {
+			t.Errorf("expected panic message naming both enums, got %s", msg)
+		}
+	}()
+
+	NewWithID[mixedEnum]("Explicit", 0)
+}
+
+func TestEnum_Code(t *testing.T) {
+	expected := "github.com/bruno-ga/enum.Role/Admin"
+	if code := Admin.Code(); code != expected {
+		t.Errorf("expected %s, got %s", expected, code)
+	}
+}
+
+func TestEnum_UnmarshalTextNumeric(t *testing.T) {
+	type numericTextEnum int
+
+	zero := New[numericTextEnum]("Zero")
+	one := New[numericTextEnum]("One")
+	numericName := New[numericTextEnum]("0")
+
+	var e Enum[numericTextEnum]
+	if err := e.UnmarshalText([]byte("1")); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	SetNumericText[numericTextEnum](true)
+
+	if err := e.UnmarshalText([]byte("1")); err != nil || e != one {
+		t.Errorf("expected %s, got %s (error %v)", one, e, err)
+	}
+
+	if err := e.UnmarshalText([]byte("Zero")); err != nil || e != zero {
+		t.Errorf("expected %s, got %s (error %v)", zero, e, err)
+	}
+
+	// Names take precedence over IDs.
+	if err := e.UnmarshalText([]byte("0")); err != nil || e != numericName {
+		t.Errorf("expected %s, got %s (error %v)", numericName, e, err)
+	}
+
+	if err := e.UnmarshalText([]byte("42")); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestCount(t *testing.T) {
+	if n := Count[Role](); n != 4 {
+		t.Errorf("expected 4, got %d", n)
+	}
+
+	type unregisteredEnum int
+
+	if n := Count[unregisteredEnum](); n != 0 {
+		t.Errorf("expected 0, got %d", n)
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	type unregisterEnum int
+
+	New[unregisterEnum]("One")
+	two := New[unregisterEnum]("Two")
+	two.AddAlias("Deux")
+	New[unregisterEnum]("Three")
+
+	if err := Unregister[unregisterEnum]("Two"); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+
+	if IsValidName[unregisterEnum]("Two") || IsValidName[unregisterEnum]("Deux") {
+		t.Errorf("expected Two and its alias to be removed, got them still registered")
+	}
+
+	if IsValidID[unregisterEnum](two.ID()) {
+		t.Errorf("expected ID %d to be removed, got it still registered", two.ID())
+	}
+
+	var names []string
+	for _, e := range EnumsByType[unregisterEnum]() {
+		names = append(names, e.Name())
+	}
+
+	if got := strings.Join(names, ","); got != "One,Three" {
+		t.Errorf("expected One,Three, got %s", got)
+	}
+
+	if err := Unregister[unregisterEnum]("Two"); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("expected %v, got %v", ErrUnknownName, err)
+	}
+
+	type unregisteredEnum int
+
+	if err := Unregister[unregisteredEnum]("One"); !errors.Is(err, ErrTypeNotRegistered) {
+		t.Errorf("expected %v, got %v", ErrTypeNotRegistered, err)
+	}
+
+	// The removed ID can be reused.
+	if again := NewWithID[unregisterEnum]("Two", two.ID()); again.Name() != "Two" {
+		t.Errorf("expected Two, got %s", again.Name())
+	}
+}
+
+func TestUnregister_Frozen(t *testing.T) {
+	type frozenUnregisterEnum int
+
+	New[frozenUnregisterEnum]("One")
+	Freeze[frozenUnregisterEnum]()
+
+	if err := Unregister[frozenUnregisterEnum]("One"); !errors.Is(err, errFrozenSet) {
+		t.Errorf("expected %v, got %v", errFrozenSet, err)
+	}
+
+	if !IsValidName[frozenUnregisterEnum]("One") {
+		t.Errorf("expected One to still be registered")
+	}
+}
+
+func TestNamesAndValues(t *testing.T) {
+	expected := "Unknown,Admin,User,Guest"
+
+	if got := strings.Join(Names[Role](), ","); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if got := strings.Join(Values[Role](), ","); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	type unregisteredEnum int
+
+	if names := Names[unregisteredEnum](); names == nil || len(names) != 0 {
+		t.Errorf("expected empty slice, got %v", names)
+	}
+}
+
+func TestSetMarshalInvalidAsNull(t *testing.T) {
+	type nullableEnum int
+
+	New[nullableEnum]("One")
+
+	var invalid Enum[nullableEnum]
+
+	if _, err := json.Marshal(invalid); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	SetMarshalInvalidAsNull[nullableEnum](true)
+
+	data, err := json.Marshal(struct {
+		Value Enum[nullableEnum]
+	}{})
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+
+	if string(data) != `{"Value":null}` {
+		t.Errorf("expected {\"Value\":null}, got %s", data)
+	}
+}
+
+func TestSetScanCaseInsensitive(t *testing.T) {
+	type foldEnum int
+
+	admin := New[foldEnum]("Admin")
+	admin.AddAlias("Root")
+	guest := New[foldEnum]("Guest")
+
+	var e Enum[foldEnum]
+
+	if err := e.Scan("admin"); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	SetScanCaseInsensitive[foldEnum](true)
+
+	for _, tc := range []struct {
+		value    any
+		expected Enum[foldEnum]
+	}{
+		{"admin", admin},
+		{"ADMIN", admin},
+		{[]byte("gUeSt"), guest},
+		{"Guest", guest},
+		{"rOOT", admin},
+	} {
+		if err := e.Scan(tc.value); err != nil || e != tc.expected {
+			t.Errorf("expected %s, got %s (error %v)", tc.expected, e, err)
+		}
+	}
+
+	if err := e.Scan("nobody"); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("expected %v, got %v", ErrUnknownName, err)
+	}
+}
+
+func TestGetOrNew(t *testing.T) {
+	type idempotentEnum int
+
+	one := GetOrNew[idempotentEnum]("One")
+	two := New[idempotentEnum]("Two")
+
+	if e := GetOrNew[idempotentEnum]("One"); e != one {
+		t.Errorf("expected %s, got %s", one, e)
+	}
+
+	if e := GetOrNew[idempotentEnum]("Two"); e != two {
+		t.Errorf("expected %s, got %s", two, e)
+	}
+
+	Freeze[idempotentEnum]()
+
+	if e := GetOrNew[idempotentEnum]("One"); e != one {
+		t.Errorf("expected %s, got %s", one, e)
+	}
+
+	if n := Count[idempotentEnum](); n != 2 {
+		t.Errorf("expected 2, got %d", n)
+	}
+
+	type describedEnum int
+
+	NewWithDescription[describedEnum]("Described", "Something")
+	New[describedEnum]("Plain").AddAlias("Alias")
+	NewWithID[describedEnum]("Explicit", 10)
+	New[describedEnum]("Aliased", WithAliases[describedEnum]("Other"))
+
+	for _, name := range []string{"Described", "Alias", "Explicit", "Aliased"} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic for %s, got normal execution", name)
+				}
+			}()
+
+			GetOrNew[describedEnum](name)
+		}()
+	}
+
+	// Aliases added after registration do not change the declaration.
+	if e := GetOrNew[describedEnum]("Plain"); e.Name() != "Plain" {
+		t.Errorf("expected Plain, got %s", e)
+	}
+}
+
+func TestCapacityRemainingAndExhausted(t *testing.T) {
+	type byteEnum uint8
+	type smallEnum int8
+	type bigEnum uint64
+
+	if c := Capacity[byteEnum](); c != 256 {
+		t.Errorf("expected 256, got %d", c)
+	}
+
+	if c := Capacity[smallEnum](); c != 128 {
+		t.Errorf("expected 128, got %d", c)
+	}
+
+	if c := Capacity[bigEnum](); c != math.MaxUint64 {
+		t.Errorf("expected %d, got %d", uint64(math.MaxUint64), c)
+	}
+
+	if r := Remaining[smallEnum](); r != 128 {
+		t.Errorf("expected 128, got %d", r)
+	}
+
+	New[smallEnum]("Zero")
+	New[smallEnum]("One")
+
+	// Explicit IDs do not use auto-generated capacity.
+	NewWithID[smallEnum]("Negative", -1)
+
+	if Exhausted[smallEnum]() {
+		t.Errorf("expected not exhausted, got exhausted")
+	}
+
+	if r := Remaining[smallEnum](); r != 126 {
+		t.Errorf("expected 126, got %d", r)
+	}
+
+	for i := 2; i < 128; i++ {
+		New[smallEnum](fmt.Sprintf("Value%d", i))
+	}
+
+	if r := Remaining[smallEnum](); r != 0 {
+		t.Errorf("expected 0, got %d", r)
+	}
+
+	if !Exhausted[smallEnum]() {
+		t.Errorf("expected exhausted, got not exhausted")
+	}
+
+	type unregisteredEnum int
+
+	if Exhausted[unregisteredEnum]() {
+		t.Errorf("expected not exhausted, got exhausted")
+	}
+}
+
+func TestSetScanNullStrict(t *testing.T) {
+	type notNullEnum int
+
+	one := New[notNullEnum]("One")
+
+	e := one
+
+	if err := e.Scan(nil); err != nil || e != one {
+		t.Errorf("expected %s, got %s (error %v)", one, e, err)
+	}
+
+	SetScanNullStrict[notNullEnum](true)
+
+	if err := e.Scan(nil); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	if err := e.Scan("One"); err != nil || e != one {
+		t.Errorf("expected %s, got %s (error %v)", one, e, err)
+	}
+}
+
+func TestEnum_BelongsTo(t *testing.T) {
+	expected := "github.com/bruno-ga/enum.Role"
+
+	if typeName := Admin.BelongsTo(); typeName != expected {
+		t.Errorf("expected %s, got %s", expected, typeName)
+	}
+
+	var invalid Enum[Role]
+	if typeName := invalid.BelongsTo(); typeName != expected {
+		t.Errorf("expected %s, got %s", expected, typeName)
+	}
+
+	type belongsTo interface{ BelongsTo() string }
+
+	var value any = Read
+	if b, ok := value.(belongsTo); !ok || b.BelongsTo() == Admin.BelongsTo() {
+		t.Errorf("expected %s to not belong to %s", value, Admin.BelongsTo())
+	}
+}
+
+func TestSetNameNormalizer(t *testing.T) {
+	type normalizedEnum int
+
+	SetNameNormalizer[normalizedEnum](func(name string) string {
+		return strings.ToLower(strings.TrimSpace(name))
+	})
+
+	admin := New[normalizedEnum](" Admin ")
+	admin.AddAlias("ROOT")
+
+	if admin.Name() != "admin" {
+		t.Errorf("expected admin, got %s", admin.Name())
+	}
+
+	for _, name := range []string{"admin", "ADMIN", " Admin\t", "root", " Root"} {
+		if e, err := EnumByTypeAndName[normalizedEnum](name); err != nil || e != admin {
+			t.Errorf("expected %s for %q, got %s (error %v)", admin, name, e, err)
+		}
+	}
+
+	var e Enum[normalizedEnum]
+	if err := json.Unmarshal([]byte(`"Admin"`), &e); err != nil || e != admin {
+		t.Errorf("expected %s, got %s (error %v)", admin, e, err)
+	}
+
+	if _, err := TryNewMany[normalizedEnum]("User", "USER"); !errors.Is(err, errDuplicateName) {
+		t.Errorf("expected %v, got %v", errDuplicateName, err)
+	}
+
+	if _, err := TryNewMany[normalizedEnum]("  "); !errors.Is(err, errEmptyName) {
+		t.Errorf("expected %v, got %v", errEmptyName, err)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("expected panic, got normal execution")
+			}
+		}()
+
+		New[normalizedEnum]("ADMIN")
+	}()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	SetNameNormalizer[normalizedEnum](strings.TrimSpace)
+}
+
+func TestUnregister_Normalized(t *testing.T) {
+	type normalizedUnregisterEnum int
+
+	SetNameNormalizer[normalizedUnregisterEnum](strings.TrimSpace)
+
+	New[normalizedUnregisterEnum]("Admin")
+
+	if err := Unregister[normalizedUnregisterEnum](" Admin "); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if IsValidName[normalizedUnregisterEnum]("Admin") {
+		t.Errorf("expected Admin to not be valid after unregistering")
+	}
+
+	admin := New[normalizedUnregisterEnum]("Admin")
+	if e, err := EnumByTypeAndName[normalizedUnregisterEnum]("Admin"); err != nil || e != admin {
+		t.Errorf("expected %s, got %s (error %v)", admin, e, err)
+	}
+}
+
+func TestEnum_Equal(t *testing.T) {
+	var invalid, otherInvalid Enum[Role]
+
+	admin := Enum[Role](Admin)
+	user := Enum[Role](User)
+
+	if !invalid.Equal(otherInvalid) {
+		t.Errorf("expected invalid enums to be equal")
+	}
+
+	if invalid.Equal(admin) || admin.Equal(invalid) {
+		t.Errorf("expected invalid and valid enums to not be equal")
+	}
+
+	if !admin.Equal(admin.Clone()) || !Admin.Equal(admin) {
+		t.Errorf("expected %s to be equal to itself", admin)
+	}
+
+	if admin.Equal(user) {
+		t.Errorf("expected %s to not be equal to %s", admin, user)
+	}
+
+	// Enums registered after a reset are different even with the same ID.
+	type resetEqualEnum int
+
+	before := New[resetEqualEnum]("One")
+	Reset[resetEqualEnum]()
+
+	if after := New[resetEqualEnum]("One"); before.Equal(after) {
+		t.Errorf("expected enums from different registrations to not be equal")
+	}
+}
+
+// decodeGeneric is a generic function with its own type parameter, as used by
+// code handling many enum types.
+func decodeGeneric[T constraints.Integer](name string) (Enum[T], error) {
+	return EnumByTypeAndName[T](name)
+}
+
+// firstSigned uses a narrower constraint than constraints.Integer.
+func firstSigned[T constraints.Signed](name string) (Enum[T], bool) {
+	e, err := decodeGeneric[T](name)
+	if err != nil {
+		return e, false
+	}
+
+	enums := EnumsByType[T]()
+
+	return e, len(enums) > 0 && enums[0] == e
+}
+
+func TestGenericCallers(t *testing.T) {
+	e, err := decodeGeneric[Role]("Admin")
+	if err != nil || RoleEnum(e) != Admin {
+		t.Errorf("expected %s, got %s (error %v)", Admin, e, err)
+	}
+
+	if _, err := decodeGeneric[Role]("Owner"); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("expected %v, got %v", ErrUnknownName, err)
+	}
+
+	if e, first := firstSigned[Role]("Unknown"); !first || RoleEnum(e) != UnknownRole {
+		t.Errorf("expected %s to be first, got %s (first %v)", UnknownRole, e, first)
+	}
+
+	if e, first := firstSigned[Role]("Guest"); first || RoleEnum(e) != Guest {
+		t.Errorf("expected %s to not be first, got %s (first %v)", Guest, e, first)
+	}
+
+	type narrowEnum int8
+
+	New[narrowEnum]("A")
+
+	if _, ok := firstSigned[narrowEnum]("A"); !ok {
+		t.Errorf("expected A to be first")
+	}
+
+	if n := Count[narrowEnum](); n != 1 {
+		t.Errorf("expected 1, got %d", n)
+	}
+}
+
+func TestOnce(t *testing.T) {
+	type lazyEnum int
+
+	var calls int32
+	register := func() {
+		atomic.AddInt32(&calls, 1)
+		NewMany[lazyEnum]("One", "Two", "Three")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			Once[lazyEnum](register)
+
+			if _, err := EnumByTypeAndName[lazyEnum]("Three"); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+
+	Reset[lazyEnum]()
+	Once[lazyEnum](register)
+
+	if calls != 2 || Count[lazyEnum]() != 3 {
+		t.Errorf("expected registration to run again after reset, got %d calls and %d enums", calls, Count[lazyEnum]())
+	}
+}
+
+func TestSetPanicOnInvalid(t *testing.T) {
+	type lenientEnum int
+
+	New[lenientEnum]("One")
+
+	var invalid Enum[lenientEnum]
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("expected panic, got normal execution")
+			}
+		}()
+
+		invalid.Name()
+	}()
+
+	SetPanicOnInvalid[lenientEnum](false)
+
+	if name := invalid.Name(); name != "" {
+		t.Errorf("expected empty name, got %s", name)
+	}
+
+	if id := invalid.ID(); id != 0 {
+		t.Errorf("expected ID 0, got %d", id)
+	}
+
+	if s := invalid.String(); s != "<invalid github.com/bruno-ga/enum.lenientEnum>" {
+		t.Errorf("expected <invalid github.com/bruno-ga/enum.lenientEnum>, got %s", s)
+	}
+
+	// Containers still reject invalid enums.
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	NewEnumSet(invalid)
+}
+
+func TestNew_Options(t *testing.T) {
+	type optionEnum int
+
+	zero := New[optionEnum]("Zero")
+	admin := New("Admin",
+		WithID[optionEnum](10),
+		WithDescription[optionEnum]("Full access"),
+		WithLabel[optionEnum]("Administrator"),
+		WithAliases[optionEnum]("Root", "Superuser"),
+	)
+	one := New("One", WithDescription[optionEnum]("Auto-generated ID"))
+
+	if zero.ID() != 0 || one.ID() != 1 || admin.ID() != 10 {
+		t.Errorf("expected IDs 0, 1 and 10, got %d, %d and %d", zero.ID(), one.ID(), admin.ID())
+	}
+
+	if admin.Description() != "Full access" || admin.Label() != "Administrator" || one.Description() != "Auto-generated ID" {
+		t.Errorf("expected descriptions and label to be set, got %#v and %#v", admin, one)
+	}
+
+	if e, err := EnumByTypeAndName[optionEnum]("Superuser"); err != nil || e != admin {
+		t.Errorf("expected %s, got %s (error %v)", admin, e, err)
+	}
+
+	// Nothing is registered (and no ID is used) if an option is invalid.
+	for _, opts := range [][]Option[optionEnum]{
+		{WithAliases[optionEnum]("Root")},
+		{WithAliases[optionEnum]("Two")},
+		{WithAliases[optionEnum]("")},
+		{WithID[optionEnum](10)},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic, got normal execution")
+				}
+			}()
+
+			New("Two", opts...)
+		}()
+
+		if IsValidName[optionEnum]("Two") {
+			t.Fatalf("expected Two to not be registered")
+		}
+	}
+
+	if two := New[optionEnum]("Two"); two.ID() != 2 {
+		t.Errorf("expected ID 2, got %d", two.ID())
+	}
+}
+
+func TestSetNameValidator(t *testing.T) {
+	type validatedEnum int
+
+	SetNameValidator[validatedEnum](GoIdentifierValidator)
+
+	valid := New[validatedEnum]("Valid")
+
+	// Aliases are not validated.
+	valid.AddAlias("not-an-identifier")
+
+	for _, name := range []string{"Not Valid", "1st", "func"} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic for %q, got normal execution", name)
+				}
+			}()
+
+			New[validatedEnum](name)
+		}()
+	}
+
+	if _, err := TryNewMany[validatedEnum]("Other", "not-valid"); err == nil || !strings.Contains(err.Error(), "index 1") {
+		t.Errorf("expected error for index 1, got %v", err)
+	}
+
+	errCustom := errors.New("must start with an uppercase letter")
+	SetNameValidator[validatedEnum](func(name string) error {
+		if name[0] < 'A' || name[0] > 'Z' {
+			return errCustom
+		}
+
+		return nil
+	})
+
+	if _, err := TryNewMany[validatedEnum]("lower"); !errors.Is(err, errCustom) {
+		t.Errorf("expected %v, got %v", errCustom, err)
+	}
+
+	SetNameValidator[validatedEnum](nil)
+
+	if e := New[validatedEnum]("any name"); e.Name() != "any name" {
+		t.Errorf("expected any name, got %s", e.Name())
+	}
+}
+
+func TestEnum_IsID(t *testing.T) {
+	if !Admin.IsID(1) || !Admin.IsID(Admin.ID()) {
+		t.Errorf("expected %s to have ID 1", Admin)
+	}
+
+	if Admin.IsID(User.ID()) {
+		t.Errorf("expected %s to not have ID %d", Admin, User.ID())
+	}
+
+	// The zero value is invalid, so it never has an ID, not even 0.
+	var zero Enum[Role]
+	if zero.IsID(0) || zero.IsID(UnknownRole.ID()) {
+		t.Errorf("expected invalid enum to not have ID 0")
+	}
+}
+
+func TestReserve(t *testing.T) {
+	type reservedEnum int
+
+	Reserve[reservedEnum](100)
+
+	if n := Count[reservedEnum](); n != 0 {
+		t.Errorf("expected 0, got %d", n)
+	}
+
+	for i := 0; i < 100; i++ {
+		New[reservedEnum](fmt.Sprintf("Enum%d", i))
+	}
+
+	if e, err := EnumByTypeAndID[reservedEnum](42); err != nil || e.Name() != "Enum42" {
+		t.Errorf("expected Enum42, got %s (error %v)", e, err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	Reserve[reservedEnum](10)
+}
+
+func TestEnum_UnmarshalJSONNull(t *testing.T) {
+	var optional struct {
+		Role    Enum[Role]
+		Pointer *Enum[Role]
+	}
+
+	if err := json.Unmarshal([]byte(`{"Role": null, "Pointer": null}`), &optional); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if optional.Role.Valid() || optional.Pointer != nil {
+		t.Errorf("expected invalid enum and nil pointer, got %v and %v", optional.Role, optional.Pointer)
+	}
+
+	// Null leaves existing values unchanged, even with a fallback.
+	type fallbackEnum int
+
+	unknown := WithUnknown[fallbackEnum]("Unknown")
+	one := New[fallbackEnum]("One")
+
+	e := one
+	if err := e.UnmarshalJSON([]byte(" null ")); err != nil || e != one {
+		t.Errorf("expected %s, got %s (error %v)", one, e, err)
+	}
+
+	if err := json.Unmarshal([]byte(`""`), &e); err != nil || e != unknown {
+		t.Errorf("expected %s, got %s (error %v)", unknown, e, err)
+	}
+}
+
+func TestEnum_Verbose(t *testing.T) {
+	if v := Admin.Verbose(); v != "Admin(1)" {
+		t.Errorf("expected Admin(1), got %s", v)
+	}
+
+	if s := Admin.String(); s != "Admin" {
+		t.Errorf("expected Admin, got %s", s)
+	}
+
+	var invalid Enum[Role]
+	if v := invalid.Verbose(); v != invalid.String() {
+		t.Errorf("expected %s, got %s", invalid.String(), v)
+	}
+}
+
+func TestSetScanNumeric(t *testing.T) {
+	type numericScanEnum int
+
+	New[numericScanEnum]("Zero")
+	one := New[numericScanEnum]("One")
+	named := NewWithID[numericScanEnum]("1000", 2)
+
+	var e Enum[numericScanEnum]
+	if err := e.Scan([]byte("1")); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("expected %v, got %v", ErrUnknownName, err)
+	}
+
+	SetScanNumeric[numericScanEnum](true)
+
+	for _, value := range []any{[]byte("1"), "1", []byte("One")} {
+		if err := e.Scan(value); err != nil || e != one {
+			t.Errorf("expected %s for %v, got %s (error %v)", one, value, e, err)
+		}
+	}
+
+	// Names take precedence.
+	if err := e.Scan([]byte("1000")); err != nil || e != named {
+		t.Errorf("expected %s, got %s (error %v)", named, e, err)
+	}
+
+	if err := e.Scan([]byte("42")); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("expected %v, got %v", ErrUnknownName, err)
+	}
+}
+
+func TestSetSQLLabel(t *testing.T) {
+	type sqlLabelEnum int
+
+	pending := New[sqlLabelEnum]("Pending")
+	inProgress := New[sqlLabelEnum]("InProgress")
+
+	SetSQLLabel[sqlLabelEnum](func(e Enum[sqlLabelEnum]) string {
+		return "status_" + strings.ToLower(e.Name())
+	})
+
+	for _, e := range []Enum[sqlLabelEnum]{pending, inProgress} {
+		value, err := e.Value()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		expected := "status_" + strings.ToLower(e.Name())
+		if value != expected {
+			t.Errorf("expected %q, got %q", expected, value)
+		}
+
+		var scanned Enum[sqlLabelEnum]
+		if err := scanned.Scan([]byte(expected)); err != nil || scanned != e {
+			t.Errorf("expected %s, got %s (error %v)", e, scanned, err)
+		}
+	}
+
+	// Names are still accepted.
+	var scanned Enum[sqlLabelEnum]
+	if err := scanned.Scan("Pending"); err != nil || scanned != pending {
+		t.Errorf("expected %s, got %s (error %v)", pending, scanned, err)
+	}
+
+	SetSQLLabel[sqlLabelEnum](nil)
+
+	if value, _ := pending.Value(); value != "Pending" {
+		t.Errorf("expected %q, got %q", "Pending", value)
+	}
+}
@@ -0,0 +1,108 @@
+package enum
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// maxEnumMapID is the maximum enum ID that can be used in an EnumMap. It
+// bounds the size of the backing slice.
+const maxEnumMapID = 1<<16 - 1
+
+// EnumMap is a map from enums of type T to values of type V backed by a slice
+// indexed by enum ID. As such, it only works for enums with IDs in the range
+// [0, 65535] and is most efficient when IDs are small and dense, which is
+// always the case for auto-generated IDs. Operations involving enums with IDs
+// outside of this range panic. Iteration happens in ID order. The zero value
+// is an empty map ready to use.
+type EnumMap[T constraints.Integer, V any] struct {
+	entries []enumMapEntry[T, V]
+	len     int
+}
+
+// enumMapEntry is a single entry in an EnumMap.
+type enumMapEntry[T constraints.Integer, V any] struct {
+	key   Enum[T]
+	value V
+}
+
+// NewEnumMap returns a new empty EnumMap with room for all enums currently
+// associated with type T.
+func NewEnumMap[T constraints.Integer, V any]() *EnumMap[T, V] {
+	return &EnumMap[T, V]{
+		entries: make([]enumMapEntry[T, V], 0, Count[T]()),
+	}
+}
+
+// indexForEnum returns the entries index associated with the given enum.
+func indexForEnum[T constraints.Integer](e Enum[T]) int {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	id := e.ID()
+	if id < 0 || uint64(id) > maxEnumMapID {
+		panic(fmt.Sprintf("enum ID %d can not be used in an enum map", id))
+	}
+
+	return int(id)
+}
+
+// Get returns the value associated with the given enum. The boolean result is
+// false if there is no such value. Values set for an enum that was later
+// unregistered are not returned for a different enum reusing its ID.
+func (m *EnumMap[T, V]) Get(e Enum[T]) (V, bool) {
+	i := indexForEnum(e)
+	if i >= len(m.entries) || m.entries[i].key != e {
+		var zero V
+		return zero, false
+	}
+
+	return m.entries[i].value, true
+}
+
+// Set associates the given value with the given enum, replacing any existing
+// value.
+func (m *EnumMap[T, V]) Set(e Enum[T], value V) {
+	i := indexForEnum(e)
+	if i >= len(m.entries) {
+		m.entries = append(m.entries, make([]enumMapEntry[T, V], i+1-len(m.entries))...)
+	}
+
+	if !m.entries[i].key.Valid() {
+		m.len++
+	}
+
+	m.entries[i] = enumMapEntry[T, V]{e, value}
+}
+
+// Delete removes the value associated with the given enum, if any.
+func (m *EnumMap[T, V]) Delete(e Enum[T]) {
+	i := indexForEnum(e)
+	if i >= len(m.entries) || m.entries[i].key != e {
+		return
+	}
+
+	m.entries[i] = enumMapEntry[T, V]{}
+	m.len--
+}
+
+// Len returns the number of values in the map.
+func (m *EnumMap[T, V]) Len() int {
+	return m.len
+}
+
+// Range calls fn for each enum and associated value in the map, in ID order,
+// until fn returns false or there are no more values.
+func (m *EnumMap[T, V]) Range(fn func(Enum[T], V) bool) {
+	for _, entry := range m.entries {
+		if !entry.key.Valid() {
+			continue
+		}
+
+		if !fn(entry.key, entry.value) {
+			return
+		}
+	}
+}
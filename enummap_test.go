@@ -0,0 +1,112 @@
+package enum
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnumMap(t *testing.T) {
+	m := NewEnumMap[Role, int]()
+
+	if cap(m.entries) != Count[Role]() {
+		t.Errorf("expected capacity %d, got %d", Count[Role](), cap(m.entries))
+	}
+
+	m.Set(Enum[Role](Guest), 10)
+	m.Set(Enum[Role](Admin), 100)
+	m.Set(Enum[Role](Guest), 1)
+
+	if m.Len() != 2 {
+		t.Errorf("expected 2, got %d", m.Len())
+	}
+
+	if v, ok := m.Get(Enum[Role](Admin)); !ok || v != 100 {
+		t.Errorf("expected 100, got %d (found %t)", v, ok)
+	}
+
+	if _, ok := m.Get(Enum[Role](User)); ok {
+		t.Errorf("expected no value for %s", User)
+	}
+
+	var keys []Enum[Role]
+	var values []int
+	m.Range(func(e Enum[Role], v int) bool {
+		keys = append(keys, e)
+		values = append(values, v)
+		return true
+	})
+
+	if len(keys) != 2 || keys[0] != Enum[Role](Admin) || keys[1] != Enum[Role](Guest) {
+		t.Errorf("expected [%s %s], got %v", Admin, Guest, keys)
+	}
+	if len(values) != 2 || values[0] != 100 || values[1] != 1 {
+		t.Errorf("expected [100 1], got %v", values)
+	}
+
+	m.Delete(Enum[Role](Admin))
+	if _, ok := m.Get(Enum[Role](Admin)); ok || m.Len() != 1 {
+		t.Errorf("expected %s to be deleted", Admin)
+	}
+
+	var zero EnumMap[Role, string]
+	zero.Set(Enum[Role](User), "user")
+	if v, ok := zero.Get(Enum[Role](User)); !ok || v != "user" {
+		t.Errorf("expected user, got %s (found %t)", v, ok)
+	}
+}
+
+func TestEnumMap_IDTooLarge(t *testing.T) {
+	type largeMapEnum uint64
+
+	// Huge IDs would break tests decoding the registry as ints.
+	defer Reset[largeMapEnum]()
+
+	tests := map[string]Enum[largeMapEnum]{
+		"max":          NewWithID[largeMapEnum]("Max", ^largeMapEnum(0)),
+		"large":        NewWithID[largeMapEnum]("Large", 1<<40),
+		"out of range": NewWithID[largeMapEnum]("OutOfRange", maxEnumMapID+1),
+	}
+
+	for name, e := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil || !strings.Contains(r.(string), "can not be used in an enum map") {
+					t.Errorf("%s: expected enum map panic, got %v", name, r)
+				}
+			}()
+
+			var m EnumMap[largeMapEnum, int]
+			m.Set(e, 1)
+		}()
+	}
+
+	var m EnumMap[largeMapEnum, int]
+	m.Set(NewWithID[largeMapEnum]("Last", maxEnumMapID), 1)
+	if m.Len() != 1 {
+		t.Errorf("expected 1, got %d", m.Len())
+	}
+}
+
+func TestEnumMap_ReusedID(t *testing.T) {
+	type reusedMapEnum int
+
+	a := New[reusedMapEnum]("A")
+
+	var m EnumMap[reusedMapEnum, string]
+	m.Set(a, "a")
+
+	if err := Unregister[reusedMapEnum]("A"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	b := NewWithID[reusedMapEnum]("B", 0)
+
+	if v, ok := m.Get(b); ok {
+		t.Errorf("expected no value for %s, got %s", b, v)
+	}
+
+	m.Delete(b)
+	if v, ok := m.Get(a); !ok || v != "a" || m.Len() != 1 {
+		t.Errorf("expected a for %s after deleting %s, got %s (found %t)", a, b, v, ok)
+	}
+}
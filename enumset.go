@@ -0,0 +1,295 @@
+package enum
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"net/url"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// maxEnumSetID is the maximum enum ID that can be stored in an EnumSet.
+const maxEnumSetID = 63
+
+// EnumSet is a set of enums of type T backed by a bitmask over enum IDs. As
+// such, it only works for enums with IDs in the range [0, 63], which is
+// always the case for types with at most 64 auto-generated enums. Operations
+// involving enums with IDs outside of this range panic. The zero value is an
+// empty set ready to use.
+type EnumSet[T constraints.Integer] struct {
+	mask uint64
+}
+
+// NewEnumSet returns a new EnumSet containing the given enums.
+func NewEnumSet[T constraints.Integer](enums ...Enum[T]) EnumSet[T] {
+	var s EnumSet[T]
+	for _, e := range enums {
+		s.Add(e)
+	}
+
+	return s
+}
+
+// bitForEnum returns the bitmask bit associated with the given enum.
+func bitForEnum[T constraints.Integer](e Enum[T]) uint64 {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	bit, err := bitForID(e.ID())
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return bit
+}
+
+// bitForID returns the bitmask bit associated with the given enum ID or a
+// non-nil error if the ID does not fit in an enum set.
+func bitForID[T constraints.Integer](id T) (uint64, error) {
+	if id < 0 || uint64(id) > maxEnumSetID {
+		return 0, fmt.Errorf("enum ID %d does not fit in an enum set", id)
+	}
+
+	return 1 << uint64(id), nil
+}
+
+// Add adds the given enum to the set.
+func (s *EnumSet[T]) Add(e Enum[T]) {
+	s.mask |= bitForEnum(e)
+}
+
+// Remove removes the given enum from the set.
+func (s *EnumSet[T]) Remove(e Enum[T]) {
+	s.mask &^= bitForEnum(e)
+}
+
+// Has returns true if the given enum is in the set or false otherwise.
+func (s EnumSet[T]) Has(e Enum[T]) bool {
+	return s.mask&bitForEnum(e) != 0
+}
+
+// Len returns the number of enums in the set.
+func (s EnumSet[T]) Len() int {
+	return bits.OnesCount64(s.mask)
+}
+
+// Union returns a new set with all enums that are in either s or other.
+func (s EnumSet[T]) Union(other EnumSet[T]) EnumSet[T] {
+	return EnumSet[T]{s.mask | other.mask}
+}
+
+// Intersect returns a new set with all enums that are in both s and other.
+func (s EnumSet[T]) Intersect(other EnumSet[T]) EnumSet[T] {
+	return EnumSet[T]{s.mask & other.mask}
+}
+
+// Difference returns a new set with all enums that are in s but not in other.
+func (s EnumSet[T]) Difference(other EnumSet[T]) EnumSet[T] {
+	return EnumSet[T]{s.mask &^ other.mask}
+}
+
+// Enums returns all enums in the set, in ID order. IDs in the set that are
+// not associated with an enum anymore (because it was unregistered after
+// being added to the set) are skipped.
+func (s EnumSet[T]) Enums() []Enum[T] {
+	enums, _ := s.enums(true)
+
+	return enums
+}
+
+// enums returns all enums in the set, in ID order. If an ID in the set is not
+// associated with an enum, it is skipped if skipStale is true or a non-nil
+// error is returned otherwise.
+func (s EnumSet[T]) enums(skipStale bool) ([]Enum[T], error) {
+	enums := make([]Enum[T], 0, s.Len())
+	for mask := s.mask; mask != 0; mask &= mask - 1 {
+		e, err := getInternalEnumForID(T(bits.TrailingZeros64(mask)))
+		if err != nil {
+			if skipStale {
+				continue
+			}
+
+			return nil, err
+		}
+
+		enums = append(enums, Enum[T]{internalEnumWrapper[T]{e}})
+	}
+
+	return enums, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. The set is encoded as
+// an array of enum names in ID order, transformed as set with
+// SetJSONNameTransform. If an ID in the set is not associated
+// with an enum anymore, a non-nil error is returned.
+func (s EnumSet[T]) MarshalJSON() ([]byte, error) {
+	enums, err := s.enums(false)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(enums))
+	for _, e := range enums {
+		names = append(names, toJSONName[T](e.Name()))
+	}
+
+	return json.Marshal(names)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The source must be
+// an array of enum names, as encoded by MarshalJSON.
+func (s *EnumSet[T]) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("source should be an array of strings, got %s", data)
+	}
+
+	var newSet EnumSet[T]
+	for i, name := range names {
+		e, err := getInternalEnumForName[T](fromJSONName[T](name))
+		if err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+
+		bit, err := bitForID(e.id)
+		if err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+
+		newSet.mask |= bit
+	}
+
+	*s = newSet
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, which is
+// also used by encoding/gob. The set is encoded compactly as its bitmask
+// over enum IDs, using a varint.
+func (s EnumSet[T]) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, binary.MaxVarintLen64)
+
+	return buf[:binary.PutUvarint(buf, s.mask)], nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// accepts data produced by MarshalBinary and requires all IDs in the set to
+// be associated with enums of type T.
+func (s *EnumSet[T]) UnmarshalBinary(data []byte) error {
+	mask, n := binary.Uvarint(data)
+	if n <= 0 || n != len(data) {
+		return fmt.Errorf("invalid enum set binary data %x", data)
+	}
+
+	for m := mask; m != 0; m &= m - 1 {
+		if _, err := getInternalEnumForID(T(bits.TrailingZeros64(m))); err != nil {
+			return fmt.Errorf("invalid enum set binary data: %w", err)
+		}
+	}
+
+	s.mask = mask
+
+	return nil
+}
+
+// Prefixes of the string forms of an EnumSet produced by Encode and
+// EncodeNames.
+const (
+	enumSetMaskPrefix  = 'm'
+	enumSetNamesPrefix = 'n'
+)
+
+// Encode returns a short, URL-safe string representation of the set that can
+// be decoded with DecodeEnumSet, for storage in places like cookies. As all
+// IDs in an EnumSet fit in its bitmask, this is always the bitmask over enum
+// IDs in base64url (with an "m" prefix), which does not survive IDs changing.
+// Use EncodeNames for a representation that does.
+func (s EnumSet[T]) Encode() string {
+	var maskBytes [8]byte
+	binary.BigEndian.PutUint64(maskBytes[:], s.mask)
+
+	return string(enumSetMaskPrefix) + base64.RawURLEncoding.EncodeToString(
+		maskBytes[bits.LeadingZeros64(s.mask)/8:])
+}
+
+// EncodeNames is like Encode but returns the comma-separated names of the
+// enums in the set, in ID order and query-escaped so the result is still
+// URL-safe (with an "n" prefix). It is longer than the bitmask form but
+// survives IDs changing. If an ID in the set is not associated with an enum
+// anymore, a non-nil error is returned.
+func (s EnumSet[T]) EncodeNames() (string, error) {
+	enums, err := s.enums(false)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(enums))
+	for _, e := range enums {
+		names = append(names, url.QueryEscape(e.internalEnum.name))
+	}
+
+	return string(enumSetNamesPrefix) + strings.Join(names, ","), nil
+}
+
+// DecodeEnumSet returns the set encoded in s by EnumSet.Encode or
+// EnumSet.EncodeNames. If s is not a valid encoding or refers to IDs or names
+// not associated with enums of type T, a non-nil error is returned.
+func DecodeEnumSet[T constraints.Integer](s string) (EnumSet[T], error) {
+	if s == "" {
+		return EnumSet[T]{}, fmt.Errorf("invalid empty enum set encoding")
+	}
+
+	switch s[0] {
+	case enumSetMaskPrefix:
+		data, err := base64.RawURLEncoding.DecodeString(s[1:])
+		if err != nil || len(data) > 8 {
+			return EnumSet[T]{}, fmt.Errorf("invalid enum set encoding %q", s)
+		}
+
+		var maskBytes [8]byte
+		copy(maskBytes[8-len(data):], data)
+
+		mask := binary.BigEndian.Uint64(maskBytes[:])
+		for m := mask; m != 0; m &= m - 1 {
+			if _, err := getInternalEnumForID(T(bits.TrailingZeros64(m))); err != nil {
+				return EnumSet[T]{}, fmt.Errorf("invalid enum set encoding: %w", err)
+			}
+		}
+
+		return EnumSet[T]{mask}, nil
+	case enumSetNamesPrefix:
+		var set EnumSet[T]
+		if s == string(enumSetNamesPrefix) {
+			return set, nil
+		}
+
+		for _, escaped := range strings.Split(s[1:], ",") {
+			name, err := url.QueryUnescape(escaped)
+			if err != nil {
+				return EnumSet[T]{}, fmt.Errorf("invalid enum set encoding %q", s)
+			}
+
+			e, err := getInternalEnumForName[T](name)
+			if err != nil {
+				return EnumSet[T]{}, fmt.Errorf("invalid enum set encoding: %w", err)
+			}
+
+			bit, err := bitForID(e.id)
+			if err != nil {
+				return EnumSet[T]{}, fmt.Errorf("invalid enum set encoding: %w", err)
+			}
+
+			set.mask |= bit
+		}
+
+		return set, nil
+	default:
+		return EnumSet[T]{}, fmt.Errorf("invalid enum set encoding %q", s)
+	}
+}
@@ -0,0 +1,252 @@
+package enum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEnumSet(t *testing.T) {
+	read := Enum[Permission](Read)
+	write := Enum[Permission](Write)
+	unknown := Enum[Permission](UnknownPermission)
+
+	s := NewEnumSet(read)
+	if !s.Has(read) {
+		t.Errorf("expected set to have %s", read)
+	}
+	if s.Has(write) {
+		t.Errorf("expected set to not have %s", write)
+	}
+
+	s.Add(write)
+	if s.Len() != 2 {
+		t.Errorf("expected 2, got %d", s.Len())
+	}
+
+	s.Remove(read)
+	if s.Has(read) {
+		t.Errorf("expected set to not have %s", read)
+	}
+
+	a := NewEnumSet(unknown, read)
+	b := NewEnumSet(read, write)
+
+	if u := a.Union(b); u != NewEnumSet(unknown, read, write) {
+		t.Errorf("expected %v, got %v", NewEnumSet(unknown, read, write).Enums(), u.Enums())
+	}
+	if i := a.Intersect(b); i != NewEnumSet(read) {
+		t.Errorf("expected %v, got %v", NewEnumSet(read).Enums(), i.Enums())
+	}
+	if d := a.Difference(b); d != NewEnumSet(unknown) {
+		t.Errorf("expected %v, got %v", NewEnumSet(unknown).Enums(), d.Enums())
+	}
+
+	enums := NewEnumSet(write, unknown).Enums()
+	if len(enums) != 2 || enums[0] != unknown || enums[1] != write {
+		t.Errorf("expected [%s %s], got %v", unknown, write, enums)
+	}
+}
+
+func TestEnumSet_IDTooLarge(t *testing.T) {
+	type largeEnum int
+
+	var e Enum[largeEnum]
+	for i := 0; i <= maxEnumSetID+1; i++ {
+		e = New[largeEnum](fmt.Sprintf("Enum%d", i))
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	NewEnumSet(e)
+}
+
+func TestEnumSet_MarshalUnmarshalJSON(t *testing.T) {
+	s := NewEnumSet(Enum[Permission](Write), Enum[Permission](Read))
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if string(data) != `["Read","Write"]` {
+		t.Errorf(`expected ["Read","Write"], got %s`, data)
+	}
+
+	var newSet EnumSet[Permission]
+	if err := json.Unmarshal(data, &newSet); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if newSet != s {
+		t.Errorf("expected %v, got %v", s.Enums(), newSet.Enums())
+	}
+
+	err = json.Unmarshal([]byte(`["Read","Execute"]`), &newSet)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "Execute") {
+		t.Errorf("expected error to mention Execute, got %s", err)
+	}
+}
+
+func TestEnumSet_UnmarshalJSONIDTooLarge(t *testing.T) {
+	type largeJSONEnum int
+
+	NewWithID[largeJSONEnum]("Big", 100)
+
+	var set EnumSet[largeJSONEnum]
+	err := json.Unmarshal([]byte(`["Big"]`), &set)
+	if err == nil || !strings.Contains(err.Error(), "element 0") {
+		t.Errorf("expected error for element 0, got %v", err)
+	}
+}
+
+func TestEnumSet_MarshalBinary(t *testing.T) {
+	set := NewEnumSet(Enum[Permission](Read), Enum[Permission](Write))
+
+	data, err := set.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var decoded EnumSet[Permission]
+	if err := decoded.UnmarshalBinary(data); err != nil || decoded != set {
+		t.Errorf("expected %v, got %v (error %v)", set.Enums(), decoded.Enums(), err)
+	}
+
+	// Bit 10 is not a Permission ID.
+	if err := decoded.UnmarshalBinary([]byte{0x80, 0x08}); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected %v, got %v", ErrUnknownID, err)
+	}
+
+	if err := decoded.UnmarshalBinary(nil); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestEnumSet_StaleIDs(t *testing.T) {
+	type staleEnum int
+
+	zero := New[staleEnum]("Zero")
+	one := New[staleEnum]("One")
+
+	set := NewEnumSet(zero, one)
+
+	if err := Unregister[staleEnum]("One"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if enums := set.Enums(); len(enums) != 1 || enums[0] != zero {
+		t.Errorf("expected [%s], got %v", zero, enums)
+	}
+
+	if _, err := json.Marshal(set); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected %v, got %v", ErrUnknownID, err)
+	}
+}
+
+// isNotURLSafe returns true if r should not be used unescaped in URLs or
+// false otherwise.
+func isNotURLSafe(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.~%+,", r))
+}
+
+func TestEnumSet_EncodeDecode(t *testing.T) {
+	type encodedEnum int
+
+	a := New[encodedEnum]("A")
+	b := New[encodedEnum]("B&C, D")
+
+	var c Enum[encodedEnum]
+	for i := 2; i <= 40; i++ {
+		c = New[encodedEnum](fmt.Sprintf("LongEnumName%d", i))
+	}
+
+	tests := []struct {
+		set            EnumSet[encodedEnum]
+		encoded, names string
+	}{
+		{NewEnumSet[encodedEnum](), "m", "n"},
+		{NewEnumSet(a), "mAQ", "nA"},
+		{NewEnumSet(a, b), "mAw", "nA,B%26C%2C+D"},
+		{NewEnumSet(a, b, c), "mAQAAAAAD", "nA,B%26C%2C+D,LongEnumName40"},
+	}
+
+	for _, test := range tests {
+		encoded := test.set.Encode()
+		if encoded != test.encoded {
+			t.Errorf("expected %q for %v, got %q", test.encoded, test.set.Enums(), encoded)
+		}
+
+		names, err := test.set.EncodeNames()
+		if err != nil || names != test.names {
+			t.Errorf("expected %q for %v, got %q (error %v)", test.names, test.set.Enums(), names, err)
+		}
+
+		for _, s := range []string{encoded, names} {
+			if i := strings.IndexFunc(s, isNotURLSafe); i >= 0 {
+				t.Errorf("expected %q to be URL-safe, got %q at index %d", s, s[i], i)
+			}
+
+			decoded, err := DecodeEnumSet[encodedEnum](s)
+			if err != nil || decoded != test.set {
+				t.Errorf("expected %v for %q, got %v (error %v)", test.set.Enums(), s, decoded.Enums(), err)
+			}
+		}
+	}
+
+	// Bit 50 and the name C are not associated with encodedEnum.
+	for _, encoded := range []string{"mBAAAAAAAAA", "nA,C", "n,", "nA%", "x", "", "m!", "mAAAAAAAAAAAA"} {
+		if _, err := DecodeEnumSet[encodedEnum](encoded); err == nil {
+			t.Errorf("expected error for %q, got nil", encoded)
+		}
+	}
+
+	if _, err := DecodeEnumSet[encodedEnum]("nA,C"); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("expected %v, got %v", ErrUnknownName, err)
+	}
+
+	if _, err := DecodeEnumSet[encodedEnum]("mBAAAAAAAAA"); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected %v, got %v", ErrUnknownID, err)
+	}
+}
+
+func TestEnumSet_JSONNameTransform(t *testing.T) {
+	type transformedSetEnum int
+
+	admin := New[transformedSetEnum]("Admin")
+
+	SetJSONNameTransform[transformedSetEnum](strings.ToUpper, func(name string) string {
+		return name[:1] + strings.ToLower(name[1:])
+	})
+
+	set := NewEnumSet(admin)
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	single, err := json.Marshal(admin)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := "[" + string(single) + "]"; string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	var decoded EnumSet[transformedSetEnum]
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != set {
+		t.Errorf("expected %v, got %v (error %v)", set.Enums(), decoded.Enums(), err)
+	}
+}
@@ -0,0 +1,83 @@
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrTypeNotRegistered is returned by lookups for a type that has no
+	// enums associated with it.
+	ErrTypeNotRegistered = errors.New("enum type not registered")
+
+	// ErrUnknownName is returned by lookups for a name that is not associated
+	// with any enum of the given type.
+	ErrUnknownName = errors.New("unknown enum name")
+
+	// ErrUnknownID is returned by lookups for an ID that is not associated
+	// with any enum of the given type.
+	ErrUnknownID = errors.New("unknown enum ID")
+)
+
+// LookupError is the error returned when an enum lookup by name or ID fails.
+// It wraps one of ErrTypeNotRegistered, ErrUnknownName or ErrUnknownID, so
+// both errors.Is and errors.As can be used with it.
+type LookupError struct {
+	// TypeName is the fully qualified name of the enum type.
+	TypeName string
+
+	// Name is the name that was looked up. Empty for lookups by ID.
+	Name string
+
+	// ID is the ID that was looked up. Nil for lookups by name.
+	ID any
+
+	// Err is the sentinel error describing the failure.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *LookupError) Error() string {
+	if e.Err == ErrTypeNotRegistered {
+		return fmt.Sprintf("%s: no enum set associated with type %s", e.Err, e.TypeName)
+	}
+
+	key := "name " + e.Name
+	if e.ID != nil {
+		key = fmt.Sprintf("id %v", e.ID)
+	}
+
+	return fmt.Sprintf("%s: %s could not be found in enum set for type %s", e.Err, key, e.TypeName)
+}
+
+// Unwrap returns the sentinel error wrapped by e.
+func (e *LookupError) Unwrap() error {
+	return e.Err
+}
+
+// multiError is an error combining several errors, one per line. It is
+// equivalent to the error returned by errors.Join, which is not available in
+// all supported Go versions.
+type multiError struct {
+	errs []error
+}
+
+// Error implements the error interface.
+func (e *multiError) Error() string {
+	var b strings.Builder
+	for i, err := range e.errs {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+
+		b.WriteString(err.Error())
+	}
+
+	return b.String()
+}
+
+// Unwrap returns the errors combined by e.
+func (e *multiError) Unwrap() []error {
+	return e.errs
+}
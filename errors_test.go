@@ -0,0 +1,71 @@
+package enum
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	type unregisteredEnum int
+
+	if _, err := EnumByTypeAndName[unregisteredEnum]("Admin"); !errors.Is(err, ErrTypeNotRegistered) {
+		t.Errorf("expected ErrTypeNotRegistered, got %v", err)
+	}
+
+	if _, err := EnumByTypeAndID[unregisteredEnum](0); !errors.Is(err, ErrTypeNotRegistered) {
+		t.Errorf("expected ErrTypeNotRegistered, got %v", err)
+	}
+
+	if _, err := EnumByTypeAndName[Role]("Owner"); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("expected ErrUnknownName, got %v", err)
+	}
+
+	if _, err := EnumByTypeAndID[Role](42); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected ErrUnknownID, got %v", err)
+	}
+
+	var role RoleEnum
+	if err := role.UnmarshalText([]byte("Owner")); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("expected ErrUnknownName, got %v", err)
+	}
+
+	e, err := EnumByTypeAndID[Role](Admin.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if RoleEnum(e) != Admin {
+		t.Errorf("expected %s, got %s", Admin, e)
+	}
+}
+
+func TestLookupError(t *testing.T) {
+	_, err := EnumByTypeAndName[Role]("Owner")
+
+	var lookupErr *LookupError
+	if !errors.As(err, &lookupErr) {
+		t.Fatalf("expected *LookupError, got %T", err)
+	}
+
+	if lookupErr.TypeName != getTypeName[Role]() {
+		t.Errorf("expected type name %s, got %s", getTypeName[Role](), lookupErr.TypeName)
+	}
+	if lookupErr.Name != "Owner" {
+		t.Errorf("expected name Owner, got %s", lookupErr.Name)
+	}
+	if lookupErr.ID != nil {
+		t.Errorf("expected nil ID, got %v", lookupErr.ID)
+	}
+
+	_, err = EnumByTypeAndID[Role](42)
+
+	if !errors.As(err, &lookupErr) {
+		t.Fatalf("expected *LookupError, got %T", err)
+	}
+
+	if lookupErr.ID != Role(42) {
+		t.Errorf("expected ID 42, got %v", lookupErr.ID)
+	}
+	if !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected ErrUnknownID, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+// Package csvtest verifies that structs with enum fields round-trip through
+// gocarina/gocsv. It is a separate module so the enum module itself stays
+// free of dependencies.
+package csvtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bruno-ga/enum"
+	"github.com/gocarina/gocsv"
+)
+
+type role int
+
+var (
+	roleAdmin = enum.New[role]("Admin")
+	roleGuest = enum.New[role]("Guest")
+)
+
+type user struct {
+	Name string          `csv:"name"`
+	Role enum.Enum[role] `csv:"role"`
+}
+
+func TestCSV(t *testing.T) {
+	users := []*user{{"alice", roleAdmin}, {"bob", roleGuest}}
+
+	data, err := gocsv.MarshalString(users)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := "name,role\nalice,Admin\nbob,Guest\n"; data != expected {
+		t.Errorf("expected %q, got %q", expected, data)
+	}
+
+	var decoded []*user
+	if err := gocsv.UnmarshalString(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(decoded) != len(users) {
+		t.Fatalf("expected %d users, got %d", len(users), len(decoded))
+	}
+
+	for i, u := range decoded {
+		if *u != *users[i] {
+			t.Errorf("expected %+v, got %+v", *users[i], *u)
+		}
+	}
+}
+
+func TestCSV_UnknownName(t *testing.T) {
+	var decoded []*user
+	err := gocsv.UnmarshalString("name,role\ncarol,Owner\n", &decoded)
+	if err == nil || !strings.Contains(err.Error(), `"Owner"`) {
+		t.Errorf("expected error mentioning Owner, got %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/bruno-ga/enum/internal/csvtest
+
+go 1.18
+
+require (
+	github.com/bruno-ga/enum v0.0.0
+	github.com/gocarina/gocsv v0.0.0-20260926200228-b2c6eb8fefab
+)
+
+require golang.org/x/exp v0.0.0-20220518171630-0b5c67f07fdf // indirect
+
+replace github.com/bruno-ga/enum => ../..
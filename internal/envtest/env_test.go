@@ -0,0 +1,49 @@
+// Package envtest verifies that enum fields can be bound from environment
+// variables with caarlos0/env. It is a separate module so the enum module
+// itself stays free of dependencies.
+package envtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bruno-ga/enum"
+	"github.com/caarlos0/env/v11"
+)
+
+type role int
+
+var roleAdmin = enum.New[role]("Admin")
+
+type config struct {
+	Role enum.Enum[role] `env:"ROLE"`
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("ROLE", "Admin")
+
+	var c config
+	if err := env.Parse(&c); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if c.Role != roleAdmin {
+		t.Errorf("expected %s, got %s", roleAdmin, c.Role)
+	}
+}
+
+func TestEnv_UnknownName(t *testing.T) {
+	t.Setenv("ROLE", "Admn")
+
+	// The env package does not wrap field errors, so only the message can be
+	// checked.
+	var c config
+	err := env.Parse(&c)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), `"Admn"`) || !strings.Contains(err.Error(), enum.ErrUnknownName.Error()) {
+		t.Errorf("expected error to include the attempted value and the cause, got %s", err)
+	}
+}
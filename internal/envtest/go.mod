@@ -0,0 +1,12 @@
+module github.com/bruno-ga/enum/internal/envtest
+
+go 1.18
+
+require (
+	github.com/bruno-ga/enum v0.0.0
+	github.com/caarlos0/env/v11 v11.4.1
+)
+
+require golang.org/x/exp v0.0.0-20220518171630-0b5c67f07fdf // indirect
+
+replace github.com/bruno-ga/enum => ../..
@@ -0,0 +1,15 @@
+module github.com/bruno-ga/enum/internal/msgpacktest
+
+go 1.18
+
+require (
+	github.com/bruno-ga/enum v0.0.0
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	golang.org/x/exp v0.0.0-20220518171630-0b5c67f07fdf // indirect
+)
+
+replace github.com/bruno-ga/enum => ../..
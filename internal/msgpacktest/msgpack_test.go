@@ -0,0 +1,58 @@
+// Package msgpacktest verifies that enums round-trip through msgpack. It is a
+// separate module so the enum module itself stays free of dependencies.
+package msgpacktest
+
+import (
+	"testing"
+
+	"github.com/bruno-ga/enum"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type role int
+
+var (
+	roleUnknown = enum.New[role]("Unknown")
+	roleAdmin   = enum.New[role]("Admin")
+)
+
+type user struct {
+	Name string
+	Role enum.Enum[role]
+}
+
+func TestMsgpack(t *testing.T) {
+	for _, u := range []user{{"admin", roleAdmin}, {"unknown", roleUnknown}} {
+		data, err := msgpack.Marshal(u)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		var decoded user
+		if err := msgpack.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if decoded != u {
+			t.Errorf("expected %+v, got %+v", u, decoded)
+		}
+	}
+}
+
+func TestMsgpack_InvalidID(t *testing.T) {
+	type rawUser struct {
+		Name string
+		Role []byte
+	}
+
+	// Varint 84 is ID 42, which is not a role.
+	data, err := msgpack.Marshal(rawUser{"nobody", []byte{84}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var decoded user
+	if err := msgpack.Unmarshal(data, &decoded); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/bruno-ga/enum/internal/pflagtest
+
+go 1.18
+
+require (
+	github.com/bruno-ga/enum v0.0.0
+	github.com/spf13/pflag v1.0.10
+)
+
+require golang.org/x/exp v0.0.0-20220518171630-0b5c67f07fdf // indirect
+
+replace github.com/bruno-ga/enum => ../..
@@ -0,0 +1,52 @@
+// Package pflagtest verifies that enums can be used as spf13/pflag flags. It
+// is a separate module so the enum module itself stays free of dependencies.
+package pflagtest
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bruno-ga/enum"
+	"github.com/spf13/pflag"
+)
+
+type role int
+
+var (
+	roleUnknown = enum.New[role]("Unknown")
+	roleAdmin   = enum.New[role]("Admin")
+)
+
+func TestPflag(t *testing.T) {
+	userRole := roleUnknown
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Var(&userRole, "role", "role of the user")
+
+	if err := flags.Parse([]string{"--role", "Admin"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if userRole != roleAdmin {
+		t.Errorf("expected %s, got %s", roleAdmin, userRole)
+	}
+
+	flag := flags.Lookup("role")
+	if flag.DefValue != "Unknown" || flag.Value.Type() != userRole.BelongsTo() {
+		t.Errorf("expected default Unknown and type %s, got %s and %s", userRole.BelongsTo(), flag.DefValue, flag.Value.Type())
+	}
+}
+
+func TestPflag_UnknownName(t *testing.T) {
+	userRole := roleUnknown
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.Var(&userRole, "role", "role of the user")
+
+	err := flags.Parse([]string{"--role", "Owner"})
+	if err == nil || !strings.Contains(err.Error(), "Owner") {
+		t.Errorf("expected error mentioning Owner, got %v", err)
+	}
+}
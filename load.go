@@ -0,0 +1,41 @@
+package enum
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+
+	"golang.org/x/exp/constraints"
+)
+
+// LoadFromReader registers enums of type T with the names read from r, one
+// per line, in order. Empty lines are skipped. The context is checked before
+// each name is registered, so slow sources can be bounded with a deadline. On
+// cancellation or if a name cannot be registered, this stops and returns a
+// non-nil error, and enums registered up to that point are kept.
+func LoadFromReader[T constraints.Integer](ctx context.Context, r io.Reader) error {
+	s := getOrCreateSetForType[T]()
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		name := scanner.Text()
+		if name == "" {
+			continue
+		}
+
+		if _, err := s.TryAdd(name, options[T]{}); err != nil {
+			return fmt.Errorf("enum name at line %d: %w", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading enum names: %w", err)
+	}
+
+	return nil
+}
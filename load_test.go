@@ -0,0 +1,70 @@
+package enum
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLoadFromReader(t *testing.T) {
+	type loadedEnum int
+
+	if err := LoadFromReader[loadedEnum](context.Background(), strings.NewReader("Zero\r\nOne\n\nTwo\n")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	names := Names[loadedEnum]()
+	if strings.Join(names, ",") != "Zero,One,Two" {
+		t.Errorf("expected [Zero One Two], got %v", names)
+	}
+
+	err := LoadFromReader[loadedEnum](context.Background(), strings.NewReader("Three\nOne\nFour\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected error for line 2, got %v", err)
+	}
+
+	if !IsValidName[loadedEnum]("Three") || IsValidName[loadedEnum]("Four") {
+		t.Errorf("expected only names before the error to be registered")
+	}
+}
+
+// cancelingReader cancels a context once the first read happens.
+type cancelingReader struct {
+	r      io.Reader
+	cancel context.CancelFunc
+}
+
+func (r *cancelingReader) Read(p []byte) (int, error) {
+	defer r.cancel()
+
+	return r.r.Read(p)
+}
+
+func TestLoadFromReader_Canceled(t *testing.T) {
+	type canceledEnum int
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := &cancelingReader{strings.NewReader("Zero\nOne\n"), cancel}
+	if err := LoadFromReader[canceledEnum](ctx, r); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+
+	if n := Count[canceledEnum](); n != 0 {
+		t.Errorf("expected 0, got %d", n)
+	}
+}
+
+func TestLoadFromReader_DuplicateID(t *testing.T) {
+	type collidingEnum int
+
+	NewWithID[collidingEnum]("Explicit", 0)
+
+	err := LoadFromReader[collidingEnum](context.Background(), strings.NewReader("Zero\n"))
+	if !errors.Is(err, errDuplicateID) || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected %v for line 1, got %v", errDuplicateID, err)
+	}
+}
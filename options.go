@@ -0,0 +1,62 @@
+package enum
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// Option configures an enum created with New.
+type Option[T constraints.Integer] func(*options[T])
+
+// options holds the resolved options for a new enum.
+type options[T constraints.Integer] struct {
+	id          T
+	hasID       bool
+	description string
+	label       string
+	aliases     []string
+}
+
+// resolveOptions returns the result of applying all the given options.
+func resolveOptions[T constraints.Integer](opts []Option[T]) options[T] {
+	var o options[T]
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithID sets an explicit ID for the enum instead of the auto-generated one.
+// Any value of T is accepted, including negative ones for signed types.
+// Explicit IDs do not count towards the auto-generated ID capacity of the
+// type.
+func WithID[T constraints.Integer](id T) Option[T] {
+	return func(o *options[T]) {
+		o.id = id
+		o.hasID = true
+	}
+}
+
+// WithDescription sets a human-readable description for the enum (for
+// tooltips, documentation, etc). It is not used for lookups.
+func WithDescription[T constraints.Integer](description string) Option[T] {
+	return func(o *options[T]) {
+		o.description = description
+	}
+}
+
+// WithLabel sets a display label for the enum. The label is only meant for
+// presentation. Marshalling and lookups always use the canonical name.
+func WithLabel[T constraints.Integer](label string) Option[T] {
+	return func(o *options[T]) {
+		o.label = label
+	}
+}
+
+// WithAliases registers additional names that resolve to the enum in lookups
+// (see AddAlias).
+func WithAliases[T constraints.Integer](aliases ...string) Option[T] {
+	return func(o *options[T]) {
+		o.aliases = append(o.aliases, aliases...)
+	}
+}
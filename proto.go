@@ -0,0 +1,41 @@
+package enum
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ProtoValue returns the ID of this Enum instance as an int32, the type used
+// by protobuf enums. This panics if the ID does not fit in an int32.
+func (e internalEnumWrapper[T]) ProtoValue() int32 {
+	if !e.Valid() {
+		panic("enum not initialized")
+	}
+
+	id := e.internalEnum.id
+
+	v := int32(id)
+	if T(v) != id || (v < 0) != (id < 0) {
+		panic(fmt.Sprintf("enum ID %d does not fit in a protobuf enum value", id))
+	}
+
+	return v
+}
+
+// FromProto returns the Enum associated with type T whose ID matches the
+// given protobuf enum value. If the value does not fit in T or no such enum
+// exists, a non-nil error is returned.
+func FromProto[T constraints.Integer](v int32) (Enum[T], error) {
+	id := T(v)
+	if (v < 0 && !isSigned[T]()) || int64(id) != int64(v) {
+		return Enum[T]{}, fmt.Errorf("protobuf enum value %d out of range for type %s", v, getTypeName[T]())
+	}
+
+	e, err := getInternalEnumForID(id)
+	if err != nil {
+		return Enum[T]{}, err
+	}
+
+	return Enum[T]{internalEnumWrapper[T]{e}}, nil
+}
@@ -0,0 +1,70 @@
+package enum
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEnum_ProtoValue(t *testing.T) {
+	if v := Admin.ProtoValue(); v != 1 {
+		t.Errorf("expected 1, got %d", v)
+	}
+
+	type bigEnum int64
+
+	big := NewWithID[bigEnum]("Big", math.MaxInt32+1)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	big.ProtoValue()
+}
+
+func TestFromProto(t *testing.T) {
+	e, err := FromProto[Role](Admin.ProtoValue())
+	if err != nil || RoleEnum(e) != Admin {
+		t.Errorf("expected %s, got %s (error %v)", Admin, e, err)
+	}
+
+	if _, err := FromProto[Role](42); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected %v, got %v", ErrUnknownID, err)
+	}
+
+	type byteEnum uint8
+	type negativeEnum int8
+
+	New[byteEnum]("Zero")
+	minusOne := NewWithID[negativeEnum]("MinusOne", -1)
+
+	for _, v := range []int32{-1, 256, math.MaxInt32} {
+		if _, err := FromProto[byteEnum](v); err == nil || errors.Is(err, ErrUnknownID) {
+			t.Errorf("expected out of range error for %d, got %v", v, err)
+		}
+	}
+
+	if _, err := FromProto[negativeEnum](-129); err == nil || errors.Is(err, ErrUnknownID) {
+		t.Errorf("expected out of range error, got %v", err)
+	}
+
+	if e, err := FromProto[negativeEnum](-1); err != nil || e != minusOne {
+		t.Errorf("expected %s, got %s (error %v)", minusOne, e, err)
+	}
+}
+
+func TestEnum_ProtoValueUnsigned(t *testing.T) {
+	type unsignedEnum uint32
+
+	large := NewWithID[unsignedEnum]("Large", math.MaxInt32+1)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	large.ProtoValue()
+}
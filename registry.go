@@ -0,0 +1,258 @@
+package enum
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// RegistrySnapshot is an opaque copy of the state of all enum sets, as
+// returned by Snapshot. It is safe to hold and to restore multiple times.
+//
+// A snapshot can be persisted with MarshalJSON, which encodes the same object
+// as DumpRegistry, and read back with UnmarshalJSON so Compatible can compare
+// the enums of different versions of a program. Decoded snapshots only carry
+// type names, names and IDs, so they cannot be passed to Restore.
+type RegistrySnapshot struct {
+	sets  map[reflect.Type]untypedSet
+	onces map[reflect.Type]any // Guards used by Once, as stored in onceByType.
+
+	// entries holds the enums of a snapshot decoded by UnmarshalJSON, keyed
+	// by type name. It is nil for snapshots returned by Snapshot.
+	entries map[string][]setEntry
+}
+
+// dumpEntry is the JSON representation of an enum used by DumpRegistry and
+// RegistrySnapshot.
+type dumpEntry struct {
+	Name string      `json:"name"`
+	ID   json.Number `json:"id"`
+}
+
+// cloneSets returns a copy of the given sets.
+func cloneSets(sets map[reflect.Type]untypedSet) map[reflect.Type]untypedSet {
+	c := make(map[reflect.Type]untypedSet, len(sets))
+	for tType, s := range sets {
+		c[tType] = s.Clone()
+	}
+
+	return c
+}
+
+// Snapshot returns a copy of the state of all enum sets (registered enums,
+// per-type settings and the guards used by Once) that can later be passed to
+// Restore. This is intended to be used in tests that register temporary
+// enums.
+func Snapshot() RegistrySnapshot {
+	setByTypeMu.RLock()
+	defer setByTypeMu.RUnlock()
+
+	onces := make(map[reflect.Type]any)
+	onceByType.Range(func(tType, once any) bool {
+		onces[tType.(reflect.Type)] = once
+		return true
+	})
+
+	return RegistrySnapshot{sets: cloneSets(setByType), onces: onces}
+}
+
+// Restore replaces the state of all enum sets with the given snapshot. Enums
+// registered after the snapshot was taken are not found by lookups anymore.
+// Existing Enum instances keep working and, as enums themselves are shared
+// with the snapshot, per-enum changes (like metadata or deprecation) are not
+// rolled back. Aliases are stored in the sets, so they are rolled back.
+// Functions passed to Once after the snapshot was taken run again on the
+// next call. Restore panics if the snapshot was decoded by UnmarshalJSON.
+func Restore(snapshot RegistrySnapshot) {
+	if snapshot.entries != nil {
+		panic("enum: cannot restore a decoded registry snapshot")
+	}
+
+	sets := cloneSets(snapshot.sets)
+
+	setByTypeMu.Lock()
+	defer setByTypeMu.Unlock()
+
+	setByType = sets
+
+	onceByType.Range(func(tType, once any) bool {
+		if snapshot.onces[tType.(reflect.Type)] != once {
+			onceByType.Delete(tType)
+		}
+
+		return true
+	})
+
+	for tType, once := range snapshot.onces {
+		onceByType.Store(tType, once)
+	}
+}
+
+// Compatible compares two registry snapshots (typically one persisted by an
+// older version of a program and decoded with UnmarshalJSON, and the current
+// one) and returns a description of each change that could break previously
+// serialized data: an ID now used by a different name, a name now using a
+// different ID or a removed name or type. Types are matched by name. Added
+// enums and types are compatible. If the snapshots are compatible, this
+// returns nil.
+func Compatible(old, new RegistrySnapshot) []string {
+	oldEntries := old.entriesByTypeName()
+	newEntries := new.entriesByTypeName()
+
+	typeNames := make([]string, 0, len(oldEntries))
+	for typeName := range oldEntries {
+		typeNames = append(typeNames, typeName)
+	}
+
+	sort.Strings(typeNames)
+
+	var problems []string
+	for _, typeName := range typeNames {
+		newSetEntries, ok := newEntries[typeName]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: type removed", typeName))
+			continue
+		}
+
+		idsByName := make(map[string]string)
+		namesByID := make(map[string]string)
+		for _, entry := range newSetEntries {
+			idsByName[entry.name] = entry.id
+			namesByID[entry.id] = entry.name
+		}
+
+		for _, entry := range oldEntries[typeName] {
+			newID, hasName := idsByName[entry.name]
+			if hasName && newID != entry.id {
+				problems = append(problems, fmt.Sprintf("%s: ID of %s changed from %s to %s",
+					typeName, entry.name, entry.id, newID))
+			}
+
+			newName, hasID := namesByID[entry.id]
+			if hasID && newName != entry.name {
+				problems = append(problems, fmt.Sprintf("%s: ID %s renamed from %s to %s",
+					typeName, entry.id, entry.name, newName))
+			}
+
+			if !hasName && !hasID {
+				problems = append(problems, fmt.Sprintf("%s: %s removed", typeName, entry.name))
+			}
+		}
+	}
+
+	return problems
+}
+
+// entriesByTypeName returns the enums in the snapshot keyed by type name, as
+// encoded by MarshalJSON.
+func (snapshot RegistrySnapshot) entriesByTypeName() map[string][]setEntry {
+	if snapshot.entries != nil {
+		return snapshot.entries
+	}
+
+	sets := make([]untypedSet, 0, len(snapshot.sets))
+	for _, s := range snapshot.sets {
+		sets = append(sets, s)
+	}
+
+	return setEntriesByTypeName(sets)
+}
+
+// MarshalJSON implements the json.Marshaler interface. The snapshot is
+// encoded as the same object returned by DumpRegistry.
+func (snapshot RegistrySnapshot) MarshalJSON() ([]byte, error) {
+	return marshalEntries(snapshot.entriesByTypeName())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts the
+// output of MarshalJSON and DumpRegistry.
+func (snapshot *RegistrySnapshot) UnmarshalJSON(data []byte) error {
+	var dump map[string][]dumpEntry
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return err
+	}
+
+	entries := make(map[string][]setEntry, len(dump))
+	for typeName, dumpEntries := range dump {
+		typeEntries := make([]setEntry, 0, len(dumpEntries))
+		for _, entry := range dumpEntries {
+			typeEntries = append(typeEntries, setEntry{entry.Name, entry.ID.String()})
+		}
+
+		entries[typeName] = typeEntries
+	}
+
+	*snapshot = RegistrySnapshot{entries: entries}
+
+	return nil
+}
+
+// DumpRegistry returns a JSON object describing all registered enums, for
+// debugging. Keys are type names (as returned by RegisteredTypes) and values
+// are arrays of {"name":...,"id":...} objects in ID order. As types declared
+// inside function bodies might share names, repeated type names get a "#2",
+// "#3", etc suffix.
+func DumpRegistry() ([]byte, error) {
+	setByTypeMu.RLock()
+	sets := make([]untypedSet, 0, len(setByType))
+	for _, s := range setByType {
+		sets = append(sets, s)
+	}
+	setByTypeMu.RUnlock()
+
+	return marshalEntries(setEntriesByTypeName(sets))
+}
+
+// setEntriesByTypeName returns the entries of the given sets keyed by type
+// name. Repeated type names get a "#2", "#3", etc suffix, assigned in the
+// order of their entries so the keys do not depend on map iteration order.
+func setEntriesByTypeName(sets []untypedSet) map[string][]setEntry {
+	type namedEntries struct {
+		typeName string
+		entries  []setEntry
+		key      string // Used to order sets sharing a type name.
+	}
+
+	named := make([]namedEntries, 0, len(sets))
+	for _, s := range sets {
+		setEntries := s.Entries()
+		named = append(named, namedEntries{s.TypeName(), setEntries, fmt.Sprint(setEntries)})
+	}
+
+	sort.Slice(named, func(i, j int) bool {
+		if named[i].typeName != named[j].typeName {
+			return named[i].typeName < named[j].typeName
+		}
+
+		return named[i].key < named[j].key
+	})
+
+	entries := make(map[string][]setEntry, len(named))
+	for _, n := range named {
+		key := n.typeName
+		for i := 2; entries[key] != nil; i++ {
+			key = fmt.Sprintf("%s#%d", n.typeName, i)
+		}
+
+		entries[key] = append(make([]setEntry, 0), n.entries...)
+	}
+
+	return entries
+}
+
+// marshalEntries encodes entries keyed by type name as a JSON object of
+// {"name":...,"id":...} arrays.
+func marshalEntries(entries map[string][]setEntry) ([]byte, error) {
+	dump := make(map[string][]dumpEntry, len(entries))
+	for typeName, typeEntries := range entries {
+		dumpEntries := make([]dumpEntry, 0, len(typeEntries))
+		for _, entry := range typeEntries {
+			dumpEntries = append(dumpEntries, dumpEntry{entry.name, json.Number(entry.id)})
+		}
+
+		dump[typeName] = dumpEntries
+	}
+
+	return json.Marshal(dump)
+}
@@ -0,0 +1,223 @@
+package enum
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	type snapshotEnum int
+	type temporaryEnum int
+
+	one := New[snapshotEnum]("One")
+
+	snapshot := Snapshot()
+
+	New[snapshotEnum]("Two")
+	New[temporaryEnum]("Temporary")
+
+	Restore(snapshot)
+
+	if IsValidName[snapshotEnum]("Two") {
+		t.Errorf("expected Two to not be registered after restore")
+	}
+	if IsValidName[temporaryEnum]("Temporary") {
+		t.Errorf("expected Temporary to not be registered after restore")
+	}
+
+	e, err := EnumByTypeAndName[snapshotEnum]("One")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if e != one {
+		t.Errorf("expected %s, got %s", one, e)
+	}
+
+	// The snapshot must be reusable.
+	New[snapshotEnum]("Two")
+	Restore(snapshot)
+
+	if IsValidName[snapshotEnum]("Two") {
+		t.Errorf("expected Two to not be registered after second restore")
+	}
+
+	if e := New[snapshotEnum]("Two"); e.ID() != 1 {
+		t.Errorf("expected ID 1, got %d", e.ID())
+	}
+}
+
+func TestSnapshotRestore_Aliases(t *testing.T) {
+	type aliasSnapshotEnum int
+
+	admin := New[aliasSnapshotEnum]("Admin")
+
+	snapshot := Snapshot()
+
+	admin.AddAlias("root")
+	Restore(snapshot)
+
+	if IsValidName[aliasSnapshotEnum]("root") {
+		t.Errorf("expected root to not be registered after restore")
+	}
+
+	if aliases := admin.Definition().Aliases; len(aliases) != 0 {
+		t.Errorf("expected no aliases after restore, got %v", aliases)
+	}
+
+	admin.AddAlias("root")
+
+	if aliases := admin.Definition().Aliases; len(aliases) != 1 || aliases[0] != "root" {
+		t.Errorf("expected [root], got %v", aliases)
+	}
+}
+
+func TestSnapshotRestore_Once(t *testing.T) {
+	type lazySnapshotEnum int
+	type eagerSnapshotEnum int
+
+	calls := 0
+	register := func() {
+		calls++
+		New[lazySnapshotEnum]("Lazy")
+	}
+
+	Once[eagerSnapshotEnum](func() { New[eagerSnapshotEnum]("Eager") })
+
+	snapshot := Snapshot()
+
+	Once[lazySnapshotEnum](register)
+	Restore(snapshot)
+
+	Once[lazySnapshotEnum](register)
+	if calls != 2 || Count[lazySnapshotEnum]() != 1 {
+		t.Errorf("expected 2 calls and 1 enum, got %d calls and %d enums", calls, Count[lazySnapshotEnum]())
+	}
+
+	// Guards in the snapshot are kept, as the enums they registered are too.
+	Once[eagerSnapshotEnum](func() { t.Errorf("expected registration to not run again") })
+}
+
+func TestCompatible(t *testing.T) {
+	type compatibleEnum int
+	type removedEnum int
+
+	New[compatibleEnum]("Zero")
+	New[compatibleEnum]("One")
+	New[compatibleEnum]("Two")
+	New[compatibleEnum]("Three")
+	New[removedEnum]("Removed")
+
+	old := Snapshot()
+
+	if problems := Compatible(old, old); problems != nil {
+		t.Errorf("expected nil, got %v", problems)
+	}
+
+	// Persist the old snapshot as an older version of a program would.
+	data, err := json.Marshal(old)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var decoded RegistrySnapshot
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if problems := Compatible(decoded, old); problems != nil {
+		t.Errorf("expected nil, got %v", problems)
+	}
+
+	Reset[compatibleEnum]()
+	Reset[removedEnum]()
+
+	New[compatibleEnum]("Zero")
+	New[compatibleEnum]("Uno")
+	NewWithID[compatibleEnum]("Three", 2)
+	NewWithID[compatibleEnum]("Four", 4)
+
+	problems := Compatible(decoded, Snapshot())
+
+	expected := []string{
+		"github.com/bruno-ga/enum.compatibleEnum: ID 1 renamed from One to Uno",
+		"github.com/bruno-ga/enum.compatibleEnum: ID 2 renamed from Two to Three",
+		"github.com/bruno-ga/enum.compatibleEnum: ID of Three changed from 3 to 2",
+		"github.com/bruno-ga/enum.removedEnum: type removed",
+	}
+
+	if strings.Join(problems, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected %v, got %v", expected, problems)
+	}
+}
+
+func TestCompatible_DumpRegistry(t *testing.T) {
+	type dumpCompatibleEnum int
+
+	New[dumpCompatibleEnum]("Zero")
+
+	data, err := DumpRegistry()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var old RegistrySnapshot
+	if err := json.Unmarshal(data, &old); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	Reset[dumpCompatibleEnum]()
+	NewWithID[dumpCompatibleEnum]("Zero", 1)
+
+	problems := Compatible(old, Snapshot())
+
+	expected := "github.com/bruno-ga/enum.dumpCompatibleEnum: ID of Zero changed from 0 to 1"
+	if len(problems) != 1 || problems[0] != expected {
+		t.Errorf("expected [%s], got %v", expected, problems)
+	}
+}
+
+func TestRestore_Decoded(t *testing.T) {
+	var decoded RegistrySnapshot
+	if err := json.Unmarshal([]byte(`{}`), &decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+
+	Restore(decoded)
+}
+
+func TestDumpRegistry(t *testing.T) {
+	type dumpedEnum int
+
+	New[dumpedEnum]("Zero")
+	NewWithID[dumpedEnum]("Negative", -1)
+
+	data, err := DumpRegistry()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var dump map[string][]struct {
+		Name string
+		ID   int
+	}
+	if err := json.Unmarshal(data, &dump); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	roles := dump["github.com/bruno-ga/enum.Role"]
+	if len(roles) != 4 || roles[0].Name != "Unknown" || roles[3].Name != "Guest" || roles[3].ID != 3 {
+		t.Errorf("expected 4 roles from Unknown to Guest, got %v", roles)
+	}
+
+	dumped := dump["github.com/bruno-ga/enum.dumpedEnum"]
+	if len(dumped) != 2 || dumped[0].Name != "Negative" || dumped[0].ID != -1 || dumped[1].Name != "Zero" {
+		t.Errorf("expected Negative and Zero, got %v", dumped)
+	}
+}
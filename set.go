@@ -1,82 +1,818 @@
 package enum
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"sync/atomic"
 
 	"golang.org/x/exp/constraints"
 )
 
+var (
+	errEmptyName     = errors.New("enum name cannot be empty")
+	errEmptyAlias    = errors.New("enum alias cannot be empty")
+	errFrozenSet     = errors.New("enum set is frozen")
+	errTooManyEnums  = errors.New("too many enums in enum set")
+	errDuplicateName = errors.New("duplicate name in enum set")
+	errDuplicateID   = errors.New("duplicate ID in enum set")
+)
+
+// untypedSet is implemented by all internalSet types and allows handling
+// sets regardless of their type parameter.
+type untypedSet interface {
+	// TypeName returns the name of the type associated with the set.
+	TypeName() string
+
+	// Names returns the names of all enums in the set, in ID order.
+	Names() []string
+
+	// Clone returns a copy of the set that can be modified independently.
+	// Enums are shared between the set and its copy.
+	Clone() untypedSet
+
+	// Entries returns the names and formatted IDs of all enums in the set, in
+	// ID order.
+	Entries() []setEntry
+}
+
+// setEntry describes an enum independently of its type.
+type setEntry struct {
+	name string
+	id   string // Formatted in base 10.
+}
+
 // internalSet collects all enums associated with a specific type T.
 type internalSet[T constraints.Integer] struct {
-	nameEnumMap map[string]*internalEnum[T]
+	// mu guards the maps and the ordered slice below. Exported methods take
+	// care of locking and unexported ones expect the caller to hold it.
+	mu sync.RWMutex
+
+	nameEnumMap  map[string]*internalEnum[T]
+	aliasEnumMap map[string]*internalEnum[T]
+	idEnumMap    map[T]*internalEnum[T]
+	orderedEnums []*internalEnum[T] // Sorted by ID. Copied on removal or non-append insertion.
 
-	nextID      int64 // Atomically updated.
-	exhaustedID bool  // Set to true when there are no more IDs available.
+	// enumAliases holds the aliases of each enum, in the order they were
+	// added. They are kept in the set instead of in the enums, which are
+	// shared between clones, so restoring a snapshot also restores aliases.
+	enumAliases map[*internalEnum[T]][]string
+
+	cacheMu     sync.Mutex
+	cachedEnums []Enum[T] // Built from orderedEnums on demand. Guarded by cacheMu.
+
+	nextID      uint64 // Atomically updated.
+	exhaustedID bool   // Set to true when there are no more IDs available.
+
+	strictText     bool // If true, UnmarshalText does not trim line terminators.
+	numericText    bool // If true, UnmarshalText also accepts IDs.
+	trimSpace      bool // If true, UnmarshalJSON trims whitespace around names.
+	invalidAsNull  bool // If true, MarshalJSON encodes invalid enums as null.
+	scanFold       bool // If true, Scan matches names case-insensitively.
+	scanNullStrict bool // If true, Scan returns an error for NULL.
+	scanNumeric    bool // If true, Scan also accepts IDs.
+	lenient        bool // If true, Name and ID return zero values for invalid enums.
+	frozen         bool // If true, no more enums can be added.
+
+	encoding        EncodingMode         // Used by MarshalJSON.
+	stringFormatter func(Enum[T]) string // Used by String, if not nil.
+	sqlLabel        func(Enum[T]) string // Used by Value and Scan, if not nil.
+
+	graphQLNameTransform func(string) string // Used by GraphQLEnum, if not nil.
+	jsonNameTo           func(string) string // Used by MarshalJSON, if not nil.
+	jsonNameFrom         func(string) string // Used by UnmarshalJSON, if not nil.
+
+	// normalizer is applied to all names and aliases when adding and looking
+	// up enums, if not nil. It can only be set while the set is empty.
+	normalizer func(string) string
+
+	validator func(string) error // Applied to normalized names when adding enums, if not nil.
+
+	fallback *internalEnum[T] // Used when decoding unknown names, if not nil.
 }
 
 // newInternalSet returns a new empty set.
 func newInternalSet[T constraints.Integer]() *internalSet[T] {
 	return &internalSet[T]{
-		make(map[string]*internalEnum[T]),
-		0,
-		false,
+		nameEnumMap:  make(map[string]*internalEnum[T]),
+		aliasEnumMap: make(map[string]*internalEnum[T]),
+		idEnumMap:    make(map[T]*internalEnum[T]),
+		enumAliases:  make(map[*internalEnum[T]][]string),
 	}
 }
 
-// Add adds a new enum with the given name to the set. The enum ID is
-// auto-generated based on the instantiation order of enums. This panics if
-// an attempt is made to add an enum with a name that already exists in the
-// set.
-func (s *internalSet[T]) Add(name string) *internalEnum[T] {
+// Add adds a new enum with the given name and options to the set. Unless an
+// explicit ID is given, the enum ID is auto-generated based on the
+// instantiation order of enums. This panics if an attempt is made to add an
+// enum with a name, alias or ID that already exists in the set, if there are
+// no more IDs available for the type T or if the set is frozen. Nothing is
+// added in that case.
+func (s *internalSet[T]) Add(name string, opts options[T]) *internalEnum[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.add(name, opts)
+}
+
+// Reserve presizes the set for n enums. This panics if the set is not empty.
+func (s *internalSet[T]) Reserve(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.orderedEnums) > 0 {
+		panic("enum set must be empty to reserve space")
+	}
+
+	s.nameEnumMap = make(map[string]*internalEnum[T], n)
+	s.idEnumMap = make(map[T]*internalEnum[T], n)
+	s.orderedEnums = make([]*internalEnum[T], 0, n)
+}
+
+// GetOrAdd returns the enum with the given name (but not alias) or, if there
+// is none, adds it like Add without options. Both happen atomically.
+func (s *internalSet[T]) GetOrAdd(name string) *internalEnum[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.nameEnumMap[s.Normalize(name)]; ok {
+		return e
+	}
+
+	return s.add(name, options[T]{})
+}
+
+// TryAdd is like Add but returns a non-nil error instead of panicking.
+func (s *internalSet[T]) TryAdd(name string, opts options[T]) (*internalEnum[T], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tryAdd(name, opts)
+}
+
+// TryAddMany adds new enums with the given names and auto-generated IDs to
+// the set, in the given order. If any of them cannot be added, nothing is
+// added and this returns a non-nil error including the index of the
+// offending name. Validation and insertion happen atomically.
+func (s *internalSet[T]) TryAddMany(names []string) ([]*internalEnum[T], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if uint64(len(names)) > s.remaining() {
+		return nil, errTooManyEnums
+	}
+
+	nextID := atomic.LoadUint64(&s.nextID)
+
+	seen := make(map[string]struct{}, len(names))
+	for i, name := range names {
+		name = s.Normalize(name)
+
+		err := s.canAdd(name)
+		if _, ok := seen[name]; ok && err == nil {
+			err = errDuplicateName
+		}
+
+		if other, ok := s.idEnumMap[T(nextID+uint64(i))]; ok && err == nil {
+			err = duplicateIDError(name, other)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("enum name at index %d: %w", i, err)
+		}
+
+		seen[name] = struct{}{}
+	}
+
+	enums := make([]*internalEnum[T], 0, len(names))
+	for _, name := range names {
+		enums = append(enums, s.add(name, options[T]{}))
+	}
+
+	return enums, nil
+}
+
+// AddUnknown is like Add without options but also sets the new enum as the
+// fallback for decoding unknown names. This panics if the enum would not get
+// ID 0, even if the enums that used it were removed.
+func (s *internalSet[T]) AddUnknown(name string) *internalEnum[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.idEnumMap[0]; ok || atomic.LoadUint64(&s.nextID) != 0 {
+		panic("unknown enum must be the first enum in enum set")
+	}
+
+	e := s.add(name, options[T]{})
+	s.fallback = e
+
+	return e
+}
+
+// add is like Add but expects the caller to hold the lock.
+func (s *internalSet[T]) add(name string, opts options[T]) *internalEnum[T] {
+	e, err := s.tryAdd(name, opts)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return e
+}
+
+// tryAdd is like TryAdd but expects the caller to hold the lock.
+func (s *internalSet[T]) tryAdd(name string, opts options[T]) (*internalEnum[T], error) {
+	name = s.Normalize(name)
+
+	var err error
+	if opts.hasID {
+		err = s.canAddName(name)
+	} else {
+		err = s.canAdd(name)
+	}
+
+	if err == nil {
+		err = s.canAddAliases(name, opts.aliases)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	id := opts.id
+	if opts.hasID {
+		if other, ok := s.idEnumMap[id]; ok {
+			return nil, duplicateIDError(name, other)
+		}
+	} else if id, err = s.reserveID(name); err != nil {
+		return nil, err
+	}
+
+	e := &internalEnum[T]{
+		name:        name,
+		id:          id,
+		description: opts.description,
+		label:       opts.label,
+
+		explicitID:      opts.hasID,
+		declaredAliases: len(opts.aliases) > 0,
+	}
+
+	s.insert(e)
+
+	for _, alias := range opts.aliases {
+		s.addAlias(e, s.Normalize(alias))
+	}
+
+	return e, nil
+}
+
+// reserveID returns the next auto-generated ID for an enum with the given
+// name. This returns a non-nil error if there are no more IDs available or if
+// the ID was already explicitly assigned to another enum, in which case no ID
+// is consumed.
+func (s *internalSet[T]) reserveID(name string) (T, error) {
+	// nextID is only advanced once the ID is known to be usable, so a failed
+	// add leaves the set unchanged.
+	newID := atomic.LoadUint64(&s.nextID)
+
+	maxID := maxIDForType[T]()
+	if newID > maxID {
+		// Not expected to be reachable as callers hold the lock and canAdd
+		// rejects sets with exhausted IDs, but kept as a safeguard.
+		s.exhaustedID = true
+		return 0, errTooManyEnums
+	}
+
+	if other, ok := s.idEnumMap[T(newID)]; ok {
+		// Auto-generated ID was already explicitly assigned.
+		return 0, duplicateIDError(name, other)
+	}
+
+	atomic.StoreUint64(&s.nextID, newID+1)
+
+	if newID == maxID {
+		// We mark IDs as exhausted as the one we just generated is valid but
+		// it is also the last one.
+		s.exhaustedID = true
+	}
+
+	return T(newID), nil
+}
+
+// duplicateIDError returns the error for an attempt to add an enum with the
+// given name using the ID already used by other.
+func duplicateIDError[T constraints.Integer](name string, other *internalEnum[T]) error {
+	return fmt.Errorf("%w: ID %d for %s is already used by %s", errDuplicateID, other.id, name, other.name)
+}
+
+// insert indexes the given enum in the set.
+func (s *internalSet[T]) insert(e *internalEnum[T]) {
+	s.nameEnumMap[e.name] = e
+	s.idEnumMap[e.id] = e
+
+	s.cacheMu.Lock()
+	s.cachedEnums = nil
+	s.cacheMu.Unlock()
+
+	i := sort.Search(len(s.orderedEnums), func(i int) bool {
+		return s.orderedEnums[i].id > e.id
+	})
+
+	if i == len(s.orderedEnums) {
+		// Common case as auto-generated IDs are monotonically increasing.
+		s.orderedEnums = append(s.orderedEnums, e)
+		return
+	}
+
+	// Copy instead of shifting in place so slices previously returned by
+	// Ordered are never modified.
+	orderedEnums := make([]*internalEnum[T], 0, len(s.orderedEnums)+1)
+	orderedEnums = append(orderedEnums, s.orderedEnums[:i]...)
+	orderedEnums = append(orderedEnums, e)
+	s.orderedEnums = append(orderedEnums, s.orderedEnums[i:]...)
+}
+
+// Remove removes the enum with the given name (but not alias) from the set,
+// including its aliases, and returns it. If there is no such enum, this
+// returns nil. If the set is frozen, nothing is removed and this returns
+// errFrozenSet.
+func (s *internalSet[T]) Remove(name string) (*internalEnum[T], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.frozen {
+		return nil, errFrozenSet
+	}
+
+	e, ok := s.nameEnumMap[s.Normalize(name)]
+	if !ok {
+		return nil, nil
+	}
+
+	delete(s.nameEnumMap, e.name)
+	delete(s.idEnumMap, e.id)
+
+	for _, alias := range s.enumAliases[e] {
+		delete(s.aliasEnumMap, alias)
+	}
+
+	delete(s.enumAliases, e)
+
+	if s.fallback == e {
+		s.fallback = nil
+	}
+
+	s.cacheMu.Lock()
+	s.cachedEnums = nil
+	s.cacheMu.Unlock()
+
+	i := s.ordinal(e)
+
+	// Copy instead of shifting in place so slices previously returned by
+	// Ordered are never modified.
+	orderedEnums := make([]*internalEnum[T], 0, len(s.orderedEnums)-1)
+	orderedEnums = append(orderedEnums, s.orderedEnums[:i]...)
+	s.orderedEnums = append(orderedEnums, s.orderedEnums[i+1:]...)
+
+	return e, nil
+}
+
+// Ordered returns all enums in the set, in ID order. The returned slice is
+// never modified by the set and must not be modified by callers.
+func (s *internalSet[T]) Ordered() []*internalEnum[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.orderedEnums
+}
+
+// Len returns the number of enums in the set.
+func (s *internalSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.orderedEnums)
+}
+
+// Enums returns all enums in the set, in ID order. The returned slice is shared
+// between callers and must not be modified.
+func (s *internalSet[T]) Enums() []Enum[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if s.cachedEnums == nil {
+		s.cachedEnums = make([]Enum[T], 0, len(s.orderedEnums))
+		for _, e := range s.orderedEnums {
+			s.cachedEnums = append(s.cachedEnums, Enum[T]{internalEnumWrapper[T]{e}})
+		}
+	}
+
+	return s.cachedEnums
+}
+
+// Ordinal returns the index of the given enum in the ID-ordered list of enums
+// in the set or -1 if the enum is not in the set.
+func (s *internalSet[T]) Ordinal(e *internalEnum[T]) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.ordinal(e)
+}
+
+// ordinal is like Ordinal but expects the caller to hold the lock.
+func (s *internalSet[T]) ordinal(e *internalEnum[T]) int {
+	i := sort.Search(len(s.orderedEnums), func(i int) bool {
+		return s.orderedEnums[i].id >= e.id
+	})
+
+	if i == len(s.orderedEnums) || s.orderedEnums[i] != e {
+		return -1
+	}
+
+	return i
+}
+
+// Ceiling returns the enum with the smallest ID greater than or equal to the
+// given one or nil if there is no such enum.
+func (s *internalSet[T]) Ceiling(id T) *internalEnum[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := sort.Search(len(s.orderedEnums), func(i int) bool {
+		return s.orderedEnums[i].id >= id
+	})
+
+	if i == len(s.orderedEnums) {
+		return nil
+	}
+
+	return s.orderedEnums[i]
+}
+
+// Higher returns the enum with the smallest ID strictly greater than the given
+// one or nil if there is no such enum.
+func (s *internalSet[T]) Higher(id T) *internalEnum[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := sort.Search(len(s.orderedEnums), func(i int) bool {
+		return s.orderedEnums[i].id > id
+	})
+
+	if i == len(s.orderedEnums) {
+		return nil
+	}
+
+	return s.orderedEnums[i]
+}
+
+// Floor returns the enum with the largest ID less than or equal to the given
+// one or nil if there is no such enum.
+func (s *internalSet[T]) Floor(id T) *internalEnum[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := sort.Search(len(s.orderedEnums), func(i int) bool {
+		return s.orderedEnums[i].id > id
+	})
+
+	if i == 0 {
+		return nil
+	}
+
+	return s.orderedEnums[i-1]
+}
+
+// canAdd returns a non-nil error describing why an enum with the given name
+// cannot be added to the set or nil if it can. It expects the caller to hold
+// the lock and the name to be normalized.
+func (s *internalSet[T]) canAdd(name string) error {
 	if s.exhaustedID {
 		// Run out of IDs.
-		panic("too many enums in enum set")
+		return errTooManyEnums
 	}
 
-	if _, ok := s.nameEnumMap[name]; ok {
-		panic("duplicate name in enum set")
+	return s.canAddName(name)
+}
+
+// canAddName is like canAdd but ignores ID exhaustion.
+func (s *internalSet[T]) canAddName(name string) error {
+	if name == "" {
+		return errEmptyName
 	}
 
-	// Reserve one ID for us and update nextID.
-	id := atomic.AddInt64(&s.nextID, 1)
-	newID := id - 1
+	if s.frozen {
+		return errFrozenSet
+	}
 
-	if T(newID) > T(id) {
-		// As we always increment by one, it is guaranteed that we will see the
-		// moment id wraps around. If Add() is being called by multiple threads,
-		// it is possible that some of those threads will not notice the wrap
-		// around but this does not matter as some other thread is still
-		// guaranteed to hit this panic here.
-		//
-		// We mark IDs as exhausthed as the one we just generated is valid.
-		s.exhaustedID = true
+	if s.hasName(name) {
+		return errDuplicateName
 	}
 
-	e := &internalEnum[T]{
-		name: name,
-		id:   T(newID),
+	if s.validator != nil {
+		if err := s.validator(name); err != nil {
+			return fmt.Errorf("invalid enum name %q: %w", name, err)
+		}
 	}
 
-	s.nameEnumMap[name] = e
+	return nil
+}
 
-	return e
+// Remaining returns how many more enums can be added to the set before
+// running out of IDs. The result saturates at math.MaxUint64.
+func (s *internalSet[T]) Remaining() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.remaining()
+}
+
+// remaining is like Remaining but expects the caller to hold the lock.
+func (s *internalSet[T]) remaining() uint64 {
+	if s.exhaustedID {
+		return 0
+	}
+
+	remaining := maxIDForType[T]() - atomic.LoadUint64(&s.nextID)
+	if remaining == math.MaxUint64 {
+		return remaining
+	}
+
+	return remaining + 1
+}
+
+// Exhausted returns true if there are no more auto-generated IDs available.
+func (s *internalSet[T]) Exhausted() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.exhaustedID
+}
+
+// Freeze prevents enums and aliases from being added to or removed from the
+// set.
+func (s *internalSet[T]) Freeze() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.frozen = true
+}
+
+// IsFrozen returns true if Freeze was called for the set or false otherwise.
+func (s *internalSet[T]) IsFrozen() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.frozen
+}
+
+// SetValidator sets the function applied to names when adding enums.
+func (s *internalSet[T]) SetValidator(fn func(string) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.validator = fn
+}
+
+// SetFallback sets the enum used when decoding unknown names.
+func (s *internalSet[T]) SetFallback(e *internalEnum[T]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fallback = e
+}
+
+// Fallback returns the enum used when decoding unknown names or nil if there
+// is none.
+func (s *internalSet[T]) Fallback() *internalEnum[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.fallback
+}
+
+// AddAlias registers an additional name that resolves to the given enum. This
+// panics if the set is frozen or if the alias is already used as a name or
+// alias in the set.
+func (s *internalSet[T]) AddAlias(e *internalEnum[T], alias string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.frozen {
+		panic(errFrozenSet.Error())
+	}
+
+	alias = s.Normalize(alias)
+
+	if s.hasName(alias) {
+		panic(errDuplicateName.Error())
+	}
+
+	s.addAlias(e, alias)
+}
+
+// Aliases returns a copy of the aliases of the given enum, in the order they
+// were added.
+func (s *internalSet[T]) Aliases(e *internalEnum[T]) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]string(nil), s.enumAliases[e]...)
+}
+
+// addAlias is like AddAlias but expects the caller to hold the lock and the
+// alias to be normalized and unused.
+func (s *internalSet[T]) addAlias(e *internalEnum[T], alias string) {
+	s.aliasEnumMap[alias] = e
+	s.enumAliases[e] = append(s.enumAliases[e], alias)
+}
+
+// canAddAliases returns a non-nil error describing why the given aliases
+// cannot be added to a new enum with the given normalized name or nil if they
+// can.
+func (s *internalSet[T]) canAddAliases(name string, aliases []string) error {
+	seen := make(map[string]struct{}, len(aliases))
+	for _, alias := range aliases {
+		if alias == "" {
+			return errEmptyAlias
+		}
+
+		alias = s.Normalize(alias)
+
+		if _, ok := seen[alias]; ok || alias == name || s.hasName(alias) {
+			return errDuplicateName
+		}
+
+		seen[alias] = struct{}{}
+	}
+
+	return nil
+}
+
+// Normalize returns the given name as transformed by the normalizer of the
+// set or unchanged if there is none.
+func (s *internalSet[T]) Normalize(name string) string {
+	if s.normalizer == nil {
+		return name
+	}
+
+	return s.normalizer(name)
 }
 
-// Get returns the enum associated with the given name. If no enum with the
-// given name exists, this returns nil.
+// hasName returns true if the given name is used either as a name or as an
+// alias in the set.
+func (s *internalSet[T]) hasName(name string) bool {
+	if _, ok := s.nameEnumMap[name]; ok {
+		return true
+	}
+
+	_, ok := s.aliasEnumMap[name]
+
+	return ok
+}
+
+// Get returns the enum associated with the given name or alias. If no enum
+// with the given name exists, this returns nil.
 func (s *internalSet[T]) Get(name string) *internalEnum[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	name = s.Normalize(name)
+
 	e, ok := s.nameEnumMap[name]
 	if !ok {
-		return nil
+		return s.aliasEnumMap[name]
 	}
 
 	return e
 }
 
+// GetFold is like Get but, if there is no exact match, matches names and
+// aliases case-insensitively. If more than one enum matches, the one with the
+// lowest ID wins (aliases are only considered after all names).
+func (s *internalSet[T]) GetFold(name string) *internalEnum[T] {
+	if e := s.Get(name); e != nil {
+		return e
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	name = s.Normalize(name)
+
+	for _, e := range s.orderedEnums {
+		if strings.EqualFold(e.name, name) {
+			return e
+		}
+	}
+
+	for _, e := range s.orderedEnums {
+		for _, alias := range s.enumAliases[e] {
+			if strings.EqualFold(alias, name) {
+				return e
+			}
+		}
+	}
+
+	return nil
+}
+
+// TypeName implements the untypedSet interface.
+func (s *internalSet[T]) TypeName() string {
+	return getTypeName[T]()
+}
+
+// Clone implements the untypedSet interface.
+func (s *internalSet[T]) Clone() untypedSet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c := &internalSet[T]{
+		nameEnumMap:     make(map[string]*internalEnum[T], len(s.nameEnumMap)),
+		aliasEnumMap:    make(map[string]*internalEnum[T], len(s.aliasEnumMap)),
+		enumAliases:     make(map[*internalEnum[T]][]string, len(s.enumAliases)),
+		idEnumMap:       make(map[T]*internalEnum[T], len(s.idEnumMap)),
+		orderedEnums:    append([]*internalEnum[T](nil), s.orderedEnums...),
+		nextID:          atomic.LoadUint64(&s.nextID),
+		exhaustedID:     s.exhaustedID,
+		strictText:      s.strictText,
+		numericText:     s.numericText,
+		trimSpace:       s.trimSpace,
+		invalidAsNull:   s.invalidAsNull,
+		scanFold:        s.scanFold,
+		scanNullStrict:  s.scanNullStrict,
+		scanNumeric:     s.scanNumeric,
+		lenient:         s.lenient,
+		frozen:          s.frozen,
+		fallback:        s.fallback,
+		encoding:        s.encoding,
+		stringFormatter: s.stringFormatter,
+		sqlLabel:        s.sqlLabel,
+
+		graphQLNameTransform: s.graphQLNameTransform,
+		jsonNameTo:           s.jsonNameTo,
+		jsonNameFrom:         s.jsonNameFrom,
+		normalizer:           s.normalizer,
+		validator:            s.validator,
+	}
+
+	for name, e := range s.nameEnumMap {
+		c.nameEnumMap[name] = e
+	}
+
+	for alias, e := range s.aliasEnumMap {
+		c.aliasEnumMap[alias] = e
+	}
+
+	for e, aliases := range s.enumAliases {
+		c.enumAliases[e] = append([]string(nil), aliases...)
+	}
+
+	for id, e := range s.idEnumMap {
+		c.idEnumMap[id] = e
+	}
+
+	return c
+}
+
+// Names implements the untypedSet interface.
+func (s *internalSet[T]) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.orderedEnums))
+	for _, e := range s.orderedEnums {
+		names = append(names, e.name)
+	}
+
+	return names
+}
+
+// Entries implements the untypedSet interface.
+func (s *internalSet[T]) Entries() []setEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]setEntry, 0, len(s.orderedEnums))
+	for _, e := range s.orderedEnums {
+		entries = append(entries, setEntry{e.name, fmt.Sprint(e.id)})
+	}
+
+	return entries
+}
+
 // GetByName returns the Enum associated with the given name and type T.
 func (s *internalSet[T]) GetByName(name string) (*internalEnum[T], error) {
-	e, ok := s.nameEnumMap[name]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.nameEnumMap[s.Normalize(name)]
 	if !ok {
 		return nil, fmt.Errorf("name %s could not be found in set", name)
 	}
@@ -86,11 +822,43 @@ func (s *internalSet[T]) GetByName(name string) (*internalEnum[T], error) {
 
 // GetByID returns the Enum associated with the given ID and type T.
 func (s *internalSet[T]) GetByID(id T) (*internalEnum[T], error) {
-	for _, e := range s.nameEnumMap {
-		if e.id == id {
-			return e, nil
-		}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.idEnumMap[id]
+	if !ok {
+		return nil, fmt.Errorf("id %d could not be found in set", id)
+	}
+
+	return e, nil
+}
+
+// HasID returns true if an enum with the given ID exists in the set or false
+// otherwise. Contrary to GetByID, it never allocates.
+func (s *internalSet[T]) HasID(id T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.idEnumMap[id]
+
+	return ok
+}
+
+// isSigned returns true if T is a signed integer type or false otherwise.
+func isSigned[T constraints.Integer]() bool {
+	var zero T
+
+	return zero-1 < zero
+}
+
+// maxIDForType returns the maximum value that can be represented by T.
+func maxIDForType[T constraints.Integer]() uint64 {
+	var zero T
+
+	bits := reflect.TypeOf(zero).Bits()
+	if isSigned[T]() {
+		return ^uint64(0) >> (65 - bits)
 	}
 
-	return nil, fmt.Errorf("id %d could not be found in set", id)
+	return ^uint64(0) >> (64 - bits)
 }
@@ -0,0 +1,23 @@
+//go:build go1.21
+
+package enum
+
+import (
+	"log/slog"
+)
+
+// LogValue implements the slog.LogValuer interface. Enums are logged as a
+// group with their name and ID. Invalid enums are logged as the same string
+// returned by String, so logging them never panics.
+func (e internalEnumWrapper[T]) LogValue() slog.Value {
+	if !e.Valid() {
+		return slog.StringValue(e.String())
+	}
+
+	id := slog.Uint64("id", uint64(e.internalEnum.id))
+	if isSigned[T]() {
+		id = slog.Int64("id", int64(e.internalEnum.id))
+	}
+
+	return slog.GroupValue(slog.String("name", e.internalEnum.name), id)
+}
@@ -0,0 +1,41 @@
+//go:build go1.21
+
+package enum
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestEnum_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	var invalid Enum[Role]
+
+	logger.Info("roles", slog.Any("role", Admin), slog.Any("invalid", invalid))
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	role, ok := record["role"].(map[string]any)
+	if !ok || role["name"] != "Admin" || role["id"] != float64(1) {
+		t.Errorf("expected role group with name Admin and id 1, got %v", record["role"])
+	}
+
+	if record["invalid"] != invalid.String() {
+		t.Errorf("expected %s, got %v", invalid.String(), record["invalid"])
+	}
+}
@@ -0,0 +1,38 @@
+package enum
+
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ByID implements sort.Interface for a slice of enums, ordering them by ID.
+// As auto-generated IDs follow declaration order, this is also the order in
+// which the enums were declared, unless some were given explicit IDs (with
+// NewWithID or WithID).
+type ByID[T constraints.Integer] []Enum[T]
+
+func (s ByID[T]) Len() int           { return len(s) }
+func (s ByID[T]) Less(i, j int) bool { return s[i].ID() < s[j].ID() }
+func (s ByID[T]) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// ByName implements sort.Interface for a slice of enums, ordering them
+// lexicographically by name.
+type ByName[T constraints.Integer] []Enum[T]
+
+func (s ByName[T]) Len() int           { return len(s) }
+func (s ByName[T]) Less(i, j int) bool { return s[i].Name() < s[j].Name() }
+func (s ByName[T]) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Sorted returns a new slice with all enums associated with the given type T,
+// sorted by less. Enums for which less reports neither order keep their ID
+// order. If no enums are associated with T, an empty slice is returned.
+func Sorted[T constraints.Integer](less func(a, b Enum[T]) bool) []Enum[T] {
+	enums := Filter(func(Enum[T]) bool { return true })
+
+	sort.SliceStable(enums, func(i, j int) bool {
+		return less(enums[i], enums[j])
+	})
+
+	return enums
+}
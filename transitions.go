@@ -0,0 +1,48 @@
+package enum
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// Transitions is a table of allowed transitions between enums of type T, for
+// enums used as states. It is backed by a bitmask matrix indexed by enum ID,
+// so, like EnumSet, it only works for enums with IDs in the range [0, 63].
+// The zero value is an empty table ready to use. A table is typically built
+// once at initialization and it is safe to call CanTransition concurrently
+// as long as Allow is not called at the same time.
+type Transitions[T constraints.Integer] struct {
+	rows []EnumSet[T] // Indexed by the ID of the source enum.
+}
+
+// Allow allows transitioning from the from enum to the to enum. This panics
+// if any of them is invalid or has an ID out of range.
+func (t *Transitions[T]) Allow(from, to Enum[T]) {
+	bitForEnum(from) // Validates from.
+
+	for uint64(from.ID()) >= uint64(len(t.rows)) {
+		t.rows = append(t.rows, EnumSet[T]{})
+	}
+
+	t.rows[from.ID()].Add(to)
+}
+
+// CanTransition returns true if transitioning from the from enum to the to
+// enum was allowed with Allow or false otherwise (including when any of them
+// is invalid).
+func (t *Transitions[T]) CanTransition(from, to Enum[T]) bool {
+	if !to.Valid() || to.ID() < 0 || uint64(to.ID()) > maxEnumSetID {
+		return false
+	}
+
+	return t.Allowed(from).Has(to)
+}
+
+// Allowed returns the set of enums that can be transitioned to from the given
+// enum.
+func (t *Transitions[T]) Allowed(from Enum[T]) EnumSet[T] {
+	if !from.Valid() || from.ID() < 0 || uint64(from.ID()) >= uint64(len(t.rows)) {
+		return EnumSet[T]{}
+	}
+
+	return t.rows[from.ID()]
+}
@@ -0,0 +1,57 @@
+package enum
+
+import (
+	"testing"
+)
+
+func TestTransitions(t *testing.T) {
+	type state int
+
+	var (
+		draft     = New[state]("Draft")
+		review    = New[state]("Review")
+		published = New[state]("Published")
+		archived  = New[state]("Archived")
+	)
+
+	var transitions Transitions[state]
+	transitions.Allow(draft, review)
+	transitions.Allow(review, draft)
+	transitions.Allow(review, published)
+	transitions.Allow(published, archived)
+
+	for _, tc := range []struct {
+		from, to Enum[state]
+		expected bool
+	}{
+		{draft, review, true},
+		{review, draft, true},
+		{review, published, true},
+		{published, archived, true},
+		{draft, published, false},
+		{archived, draft, false},
+		{draft, draft, false},
+		{Enum[state]{}, draft, false},
+		{draft, Enum[state]{}, false},
+	} {
+		if got := transitions.CanTransition(tc.from, tc.to); got != tc.expected {
+			t.Errorf("expected %v for %s -> %s, got %v", tc.expected, tc.from, tc.to, got)
+		}
+	}
+
+	if allowed := transitions.Allowed(review); allowed != NewEnumSet(draft, published) {
+		t.Errorf("expected [Draft Published], got %v", allowed.Enums())
+	}
+
+	if allowed := transitions.Allowed(archived); allowed.Len() != 0 {
+		t.Errorf("expected no transitions, got %v", allowed.Enums())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, got normal execution")
+		}
+	}()
+
+	transitions.Allow(NewWithID[state]("OutOfRange", 64), draft)
+}